@@ -0,0 +1,324 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cetteup/joinme.click-launcher/internal"
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/internal/router"
+)
+
+const (
+	registerCommand   = "register"
+	deregisterCommand = "deregister"
+	launchCommand     = "launch"
+	statusCommand     = "status"
+	doctorCommand     = "doctor"
+	listCommand       = "list"
+	historyCommand    = "history"
+	versionCommand    = "version"
+)
+
+// errUsage Command was invoked with invalid args (usage is printed instead of an error being logged)
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	name string
+	// Synopsis of the command's (non-flag) args
+	args        string
+	description string
+	// Whether to leave the window open after running the command, since it is usually not run from a terminal
+	// (e.g. by the URL protocol handler or by double-clicking the launcher)
+	keepWindowOpen bool
+	addFlags       func(flags *flag.FlagSet)
+	// run Runs the command, which logs any errors itself (returned errors only determine the exit code)
+	run func(args []string) error
+}
+
+func (c command) synopsis() string {
+	if c.args == "" {
+		return c.name
+	}
+	return fmt.Sprintf("%s %s", c.name, c.args)
+}
+
+func makeCommands(o *options) []command {
+	return []command{
+		{
+			name:           registerCommand,
+			args:           "[scheme...]",
+			description:    "register as URL protocol handler for the given games (all installed games if none are given)",
+			keepWindowOpen: true,
+			run:            runRegister,
+		},
+		{
+			name:           deregisterCommand,
+			args:           "[scheme...]",
+			description:    "remove URL protocol handlers for the given games (all games if none are given)",
+			keepWindowOpen: true,
+			run:            runDeregister,
+		},
+		{
+			name:           launchCommand,
+			args:           "<url>",
+			description:    "launch the game based on the URL",
+			keepWindowOpen: true,
+			addFlags:       o.addDryRunFlag,
+			run: func(args []string) error {
+				return runLaunch(args, o.dryRun)
+			},
+		},
+		{
+			name:        statusCommand,
+			args:        "[scheme...]",
+			description: "show whether the given games are installed and the launcher is registered for them",
+			run:         runStatus,
+		},
+		{
+			name:        doctorCommand,
+			args:        "[scheme...]",
+			description: "check whether the given installed games can be launched via URLs",
+			run:         runDoctor,
+		},
+		{
+			name:        listCommand,
+			description: "list supported games along with their URL protocol schemes, actions and mods",
+			run:         runList,
+		},
+		makeHistoryCommand(),
+		{
+			name:        versionCommand,
+			description: "print the version",
+			run:         runVersion,
+		},
+	}
+}
+
+func runRegister(args []string) error {
+	gameRouter, err := buildGameRouterForSchemes(args)
+	if err != nil {
+		return err
+	}
+
+	results := gameRouter.RegisterHandlers()
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Title.ProtocolScheme < results[j].Title.ProtocolScheme
+	})
+
+	var failed bool
+	for _, result := range results {
+		var message string
+		if result.Error != nil {
+			message = "handler registration failed"
+			failed = true
+		} else if !result.GameInstalled {
+			message = "not installed"
+		} else if result.Title.RequiresPlatformClient() && !result.PlatformClientInstalled {
+			message = fmt.Sprintf("installed, but required platform client is missing (%s)", result.Title.PlatformClient.Platform)
+		} else if result.PreviouslyRegistered {
+			message = "launcher already registered"
+		} else {
+			message = "launcher registered successfully"
+		}
+		log.Info().
+			Err(result.Error).
+			Str("game", result.Title.Name).
+			Str("result", message).
+			Msg("Checked status for")
+	}
+
+	if failed {
+		return fmt.Errorf("failed to register some handlers")
+	}
+	return nil
+}
+
+func runDeregister(args []string) error {
+	gameRouter, err := buildGameRouterForSchemes(args)
+	if err != nil {
+		return err
+	}
+
+	if err = gameRouter.DeregisterHandlers(); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to deregister handlers")
+		return err
+	}
+
+	log.Info().Msg("Successfully deregistered handlers")
+	return nil
+}
+
+func runLaunch(args []string, dryRun bool) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	gameRouter := buildGameRouterWithTitles(buildLaunchHistory(), dryRun, supportedTitles...)
+	title, err := gameRouter.RunURL(args[0])
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("game", title.String()).
+			Str("url", internal.RedactURL(args[0])).
+			Msg("Game could not be launched")
+		return err
+	}
+
+	if dryRun {
+		log.Info().
+			Str("game", title.String()).
+			Str("url", internal.RedactURL(args[0])).
+			Msg("Dry run completed, game was not launched")
+	} else {
+		log.Info().
+			Str("game", title.String()).
+			Str("url", internal.RedactURL(args[0])).
+			Msg("Game launched")
+	}
+	return nil
+}
+
+func runStatus(args []string) error {
+	gameRouter, err := buildGameRouterForSchemes(args)
+	if err != nil {
+		return err
+	}
+
+	results := gameRouter.GetHandlerStatuses()
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Title.ProtocolScheme < results[j].Title.ProtocolScheme
+	})
+
+	var failed bool
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SCHEME\tGAME\tINSTALLED\tPLATFORM CLIENT\tREGISTERED\tERROR")
+	for _, result := range results {
+		platformClient := "-"
+		if result.Title.RequiresPlatformClient() {
+			platformClient = fmt.Sprintf("%s (%s)", formatYesNo(result.PlatformClientInstalled), result.Title.PlatformClient.Platform)
+		}
+		var errMessage string
+		if result.Error != nil {
+			errMessage = result.Error.Error()
+			failed = true
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			result.Title.ProtocolScheme,
+			result.Title.Name,
+			formatYesNo(result.GameInstalled),
+			platformClient,
+			formatYesNo(result.PreviouslyRegistered),
+			errMessage,
+		)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	if failed {
+		return fmt.Errorf("failed to determine status of some games")
+	}
+	return nil
+}
+
+func runDoctor(args []string) error {
+	gameRouter, err := buildGameRouterForSchemes(args)
+	if err != nil {
+		return err
+	}
+
+	schemes := make([]string, 0, len(gameRouter.GameTitles))
+	for scheme := range gameRouter.GameTitles {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+
+	var problems int
+	for _, scheme := range schemes {
+		// Use the router's titles, since these include any custom config
+		gameTitle := gameRouter.GameTitles[scheme]
+		err = gameRouter.CheckGame(gameTitle)
+		if errors.Is(err, router.ErrGameNotInstalled) {
+			log.Debug().Str("game", gameTitle.Name).Msg("Game is not installed, skipping")
+			continue
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("game", gameTitle.Name).Msg("Game cannot be launched via URLs")
+			problems++
+			continue
+		}
+		log.Info().Str("game", gameTitle.Name).Msg("Game can be launched via URLs")
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found problems with %d game(s)", problems)
+	}
+	return nil
+}
+
+func runList(args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	gameTitles := append([]domain.GameTitle{}, supportedTitles...)
+	sort.Slice(gameTitles, func(i, j int) bool {
+		return gameTitles[i].ProtocolScheme < gameTitles[j].ProtocolScheme
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SCHEME\tGAME\tACTIONS\tMODS")
+	for _, gameTitle := range gameTitles {
+		actions := []string{domain.ActionLaunch.Name}
+		for _, action := range gameTitle.Actions {
+			actions = append(actions, action.Name)
+		}
+		mods := make([]string, 0, len(gameTitle.Mods))
+		for _, mod := range gameTitle.Mods {
+			mods = append(mods, mod.Slug)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			gameTitle.ProtocolScheme,
+			gameTitle.Name,
+			strings.Join(actions, ", "),
+			strings.Join(mods, ", "),
+		)
+	}
+	return w.Flush()
+}
+
+func runVersion(args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	fmt.Printf("joinme.click-launcher %s (%s) built at %s\n", buildVersion, buildCommit, buildTime)
+	return nil
+}
+
+// buildGameRouterForSchemes Builds a router for the games with the given schemes (all supported games if none are given)
+func buildGameRouterForSchemes(schemes []string) (*router.GameRouter, error) {
+	gameTitles, err := selectTitles(schemes)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to select games")
+		return nil, err
+	}
+	return buildGameRouterWithTitles(buildLaunchHistory(), false, gameTitles...), nil
+}
+
+func formatYesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cetteup/joinme.click-launcher/internal/history"
+)
+
+func makeHistoryCommand() command {
+	var filter history.Filter
+	var outcome string
+	return command{
+		name:        historyCommand,
+		description: "list past launches (most recent last)",
+		addFlags: func(flags *flag.FlagSet) {
+			flags.StringVar(&filter.Scheme, "game", "", "only list launches of the game with the given protocol scheme (e.g. bf2)")
+			flags.StringVar(&outcome, "outcome", "", "only list launches with the given outcome (launched, exited, dry-run, failed)")
+			flags.IntVar(&filter.Limit, "limit", 20, "list at most this many (most recent) launches (0 for no limit)")
+		},
+		run: func(args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			filter.Outcome = history.Outcome(outcome)
+			if err := printHistory(buildLaunchHistory(), filter); err != nil {
+				log.Error().Err(err).Msg("Failed to list launch history")
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// printHistory Lists past launches matching the filter
+func printHistory(launchHistory *history.History, filter history.Filter) error {
+	events, err := launchHistory.Read(filter)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tGAME\tACTION\tOUTCOME\tDETAILS\tURL")
+	for _, event := range events {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			event.Timestamp.Local().Format(time.DateTime),
+			event.Scheme,
+			event.Action,
+			event.Outcome,
+			formatEventDetails(event),
+			event.URL,
+		)
+	}
+	return w.Flush()
+}
+
+func formatEventDetails(event history.Event) string {
+	details := make([]string, 0)
+	if event.Error != "" {
+		details = append(details, event.Error)
+	}
+	if event.ExitCode != nil {
+		runtime := time.Duration(event.RuntimeMs) * time.Millisecond
+		details = append(details, fmt.Sprintf("exit code %d after %s", *event.ExitCode, runtime))
+	}
+	if event.Relaunches > 0 {
+		details = append(details, fmt.Sprintf("%d relaunch(es)", event.Relaunches))
+	}
+	return strings.Join(details, ", ")
+}
@@ -3,10 +3,11 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"sort"
+	"strings"
 	"time"
 
 	filerepo "github.com/cetteup/filerepo/pkg"
@@ -14,135 +15,253 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/cetteup/joinme.click-launcher/internal"
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/internal/history"
 	"github.com/cetteup/joinme.click-launcher/internal/router"
 	"github.com/cetteup/joinme.click-launcher/internal/titles"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
-	"github.com/cetteup/joinme.click-launcher/pkg/registry_repository"
-	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
-func init() {
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout})
+const (
+	serverQueryTimeout = 3 * time.Second
 
-	err := internal.LoadConfig()
-	if err != nil {
-		log.Err(err).Msg("Failed to load configuration from file, continuing with defaults")
-	}
+	logFormatConsole = "console"
+	logFormatJSON    = "json"
 
-	registryRepository := registry_repository.New()
-	fileRepository := filerepo.New()
-
-	gameFinder := software_finder.New(registryRepository, fileRepository)
-	gameLauncher := game_launcher.New(fileRepository)
-	gameRouter = router.New(registryRepository, gameFinder, gameLauncher)
-	gameRouter.AddTitle(
-		titles.Bf1942,
-		titles.BfVietnam,
-		titles.Bf2,
-		titles.Bf4,
-		titles.Bf1,
-		titles.Cod,
-		titles.CodUO,
-		titles.Cod2,
-		titles.Cod4,
-		titles.CodWaw,
-		titles.Fear,
-		titles.FearSec2,
-		titles.Paraworld,
-		titles.Swat4,
-		titles.Swat4X,
-		titles.Unreal,
-		titles.UT,
-		titles.UT2003,
-		titles.UT2004,
-		titles.Vietcong,
-	)
-}
+	exitCodeFailure = 1
+	exitCodeUsage   = 2
+)
 
 var (
 	buildVersion = "development"
 	buildCommit  = "uncommitted"
 	buildTime    = "unknown"
-
-	gameRouter *router.GameRouter
 )
 
+var supportedTitles = []domain.GameTitle{
+	titles.Bf1942,
+	titles.BfVietnam,
+	titles.Bf2,
+	titles.Bf4,
+	titles.Bf1,
+	titles.Cod,
+	titles.CodUO,
+	titles.Cod2,
+	titles.Cod4,
+	titles.CodWaw,
+	titles.Fear,
+	titles.FearSec2,
+	titles.Paraworld,
+	titles.Swat4,
+	titles.Swat4X,
+	titles.Unreal,
+	titles.UT,
+	titles.UT2003,
+	titles.UT2004,
+	titles.Vietcong,
+}
+
+// options Options shared by all commands
+type options struct {
+	configPath string
+	logFormat  string
+	quiet      bool
+	debug      bool
+	// Only used by the launch command, but also accepted without it (see main)
+	dryRun bool
+}
+
+func (o *options) addFlags(flags *flag.FlagSet) {
+	// Use current values as defaults, so options given before the command are not reset when parsing the command's flags
+	flags.StringVar(&o.configPath, "config", o.configPath, "load the config from the given file instead of config.yaml next to the launcher")
+	flags.StringVar(&o.logFormat, "log-format", o.logFormat, "log format (console, json)")
+	flags.BoolVar(&o.quiet, "quiet", o.quiet, "do not leave the window open any longer than required")
+	flags.BoolVar(&o.debug, "debug", o.debug, "set log level to debug")
+}
+
+func (o *options) addDryRunFlag(flags *flag.FlagSet) {
+	flags.BoolVar(&o.dryRun, "dry-run", o.dryRun, "print the command line and hooks instead of launching the game")
+}
+
+func buildGameRouterWithTitles(launchHistory *history.History, dryRun bool, gameTitles ...domain.GameTitle) *router.GameRouter {
+	gameRouter := buildGameRouter(filerepo.New(), launchHistory, dryRun)
+	gameRouter.AddTitle(gameTitles...)
+	return gameRouter
+}
+
+func buildLaunchHistory() *history.History {
+	path, err := history.BuildFilePath()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to determine launch history file path")
+	}
+	return history.New(game_launcher.NewOSFileRepository(filerepo.New()), path)
+}
+
+// selectTitles Returns the titles with the given protocol schemes, or all supported titles if none are given
+func selectTitles(schemes []string) ([]domain.GameTitle, error) {
+	if len(schemes) == 0 {
+		return supportedTitles, nil
+	}
+
+	selected := make([]domain.GameTitle, 0, len(schemes))
+	for _, scheme := range schemes {
+		gameTitle, ok := findTitle(scheme)
+		if !ok {
+			return nil, fmt.Errorf("game not supported: %s", scheme)
+		}
+		selected = append(selected, gameTitle)
+	}
+	return selected, nil
+}
+
+func findTitle(scheme string) (domain.GameTitle, bool) {
+	for _, gameTitle := range supportedTitles {
+		if gameTitle.ProtocolScheme == scheme {
+			return gameTitle, true
+		}
+	}
+	return domain.GameTitle{}, false
+}
+
+func setupLogging(o options) error {
+	switch o.logFormat {
+	case logFormatConsole:
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout})
+	case logFormatJSON:
+		log.Logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+	default:
+		return fmt.Errorf("invalid log format: %s", o.logFormat)
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	if o.debug {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	}
+
+	return nil
+}
+
 func main() {
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout})
+
+	o := &options{
+		logFormat: logFormatConsole,
+	}
+	commands := makeCommands(o)
+
+	// Launching a URL is by far the most common use case (via the URL protocol handler), so allow launch flags to be
+	// given without the launch command for backwards compatibility (e.g. joinme.click-launcher.exe -dry-run "<url>")
 	var printVersion bool
 	var deregister bool
-	var quietLaunch bool
-	var debug bool
-	flag.BoolVar(&printVersion, "v", false, "print the version")
-	flag.BoolVar(&printVersion, "version", false, "print the version")
-	flag.BoolVar(&deregister, "deregister", false, "deregister/remove game URL protocol handlers")
-	flag.BoolVar(&quietLaunch, "quiet", false, "do not leave the window open any longer than required")
-	flag.BoolVar(&debug, "debug", false, "set log level to debug")
-	flag.Parse()
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	o.addFlags(flags)
+	o.addDryRunFlag(flags)
+	flags.BoolVar(&printVersion, "v", false, "print the version (deprecated, use the version command)")
+	flags.BoolVar(&printVersion, "version", false, "print the version (deprecated, use the version command)")
+	flags.BoolVar(&deregister, "deregister", false, "deregister/remove game URL protocol handlers (deprecated, use the deregister command)")
+	flags.Usage = func() {
+		printUsage(flags, commands)
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	cmd, args, err := selectCommand(commands, flags.Args(), printVersion, deregister)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		os.Exit(exitCodeUsage)
+	}
 
-	version := fmt.Sprintf("joinme.click-launcher %s (%s) built at %s", buildVersion, buildCommit, buildTime)
+	cmdFlags := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	o.addFlags(cmdFlags)
+	if cmd.addFlags != nil {
+		cmd.addFlags(cmdFlags)
+	}
+	cmdFlags.Usage = func() {
+		printCommandUsage(cmdFlags, cmd)
+	}
+	_ = cmdFlags.Parse(args)
 
-	if printVersion {
-		fmt.Println(version)
-		os.Exit(0)
+	if err = setupLogging(*o); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		cmdFlags.Usage()
+		os.Exit(exitCodeUsage)
 	}
 
-	zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	if debug || internal.Config.DebugLogging {
+	if err = internal.LoadConfig(o.configPath); err != nil {
+		log.Err(err).Msg("Failed to load configuration from file, continuing with defaults")
+	}
+	if internal.Config.DebugLogging {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	}
 
-	args := flag.Args()
-	if deregister {
-		if err := gameRouter.DeregisterHandlers(); err != nil {
-			log.Error().
-				Err(err).
-				Msg("Failed to deregister handlers")
-		} else {
-			log.Info().Msg("Successfully deregistered handlers")
-		}
-	} else if len(args) == 0 {
-		results := gameRouter.RegisterHandlers()
-		sort.Slice(results, func(i, j int) bool {
-			return results[i].Title.ProtocolScheme < results[j].Title.ProtocolScheme
-		})
-		for _, result := range results {
-			var message string
-			if result.Error != nil {
-				message = "handler registration failed"
-			} else if !result.GameInstalled {
-				message = "not installed"
-			} else if result.GameInstalled && result.Title.RequiresPlatformClient() && !result.PlatformClientInstalled {
-				message = fmt.Sprintf("installed, but required platform client is missing (%s)", result.Title.PlatformClient.Platform)
-			} else if result.PreviouslyRegistered {
-				message = "launcher already registered"
-			} else {
-				message = "launcher registered successfully"
-			}
-			log.Info().
-				Err(result.Error).
-				Str("game", result.Title.Name).
-				Str("result", message).
-				Msg("Checked status for")
-		}
-	} else if len(args) == 1 {
-		title, err := gameRouter.RunURL(args[0])
-		if err != nil {
-			log.Error().
-				Err(err).
-				Str("game", title.String()).
-				Str("url", args[0]).
-				Msg("Game could not be launched")
-		} else {
-			log.Info().
-				Str("game", title.String()).
-				Str("url", args[0]).
-				Msg("Game launched")
+	exitCode := 0
+	if err = cmd.run(cmdFlags.Args()); err != nil {
+		exitCode = exitCodeFailure
+		if errors.Is(err, errUsage) {
+			cmdFlags.Usage()
+			exitCode = exitCodeUsage
 		}
 	}
 
 	// Leave window open for a bit unless disabled via arg or config
-	if !quietLaunch && !internal.Config.QuietLaunch {
+	if cmd.keepWindowOpen && !o.quiet && !internal.Config.QuietLaunch {
 		log.Info().Msg("Window will close in 15 seconds")
 		time.Sleep(15 * time.Second)
 	}
+
+	os.Exit(exitCode)
+}
+
+// selectCommand Determines the command to run based on the (non-flag) args, supporting the legacy invocations
+// without any command (no args: register handlers, single URL arg: launch URL, as invoked by the URL protocol handler)
+func selectCommand(commands []command, args []string, printVersion bool, deregister bool) (command, []string, error) {
+	switch {
+	case printVersion:
+		return findCommand(commands, versionCommand), nil, nil
+	case deregister:
+		return findCommand(commands, deregisterCommand), args, nil
+	case len(args) == 0:
+		return findCommand(commands, registerCommand), nil, nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd, args[1:], nil
+		}
+	}
+
+	if len(args) == 1 && strings.Contains(args[0], "://") {
+		return findCommand(commands, launchCommand), args, nil
+	}
+
+	return command{}, nil, fmt.Errorf("unknown command: %s", args[0])
+}
+
+func findCommand(commands []command, name string) command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	// Command names are constants, so this cannot happen unless a command was removed
+	panic(fmt.Sprintf("command not found: %s", name))
+}
+
+func printUsage(flags *flag.FlagSet, commands []command) {
+	out := flags.Output()
+	_, _ = fmt.Fprintf(out, "Usage: %s [options] <command> [args]\n", flags.Name())
+	_, _ = fmt.Fprintf(out, "       %s [options] <url>\n\n", flags.Name())
+	_, _ = fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(out, "  %-30s %s\n", cmd.synopsis(), cmd.description)
+	}
+	_, _ = fmt.Fprintln(out, "\nRunning the launcher without any command registers the URL handlers of all installed games.")
+	_, _ = fmt.Fprintln(out, "\nOptions:")
+	flags.PrintDefaults()
+}
+
+func printCommandUsage(flags *flag.FlagSet, cmd command) {
+	out := flags.Output()
+	_, _ = fmt.Fprintf(out, "Usage: %s [options] %s\n\n%s\n\nOptions:\n", os.Args[0], cmd.synopsis(), cmd.description)
+	flags.PrintDefaults()
 }
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"net"
+
+	filerepo "github.com/cetteup/filerepo/pkg"
+
+	"github.com/cetteup/joinme.click-launcher/internal/history"
+	"github.com/cetteup/joinme.click-launcher/internal/router"
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
+	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+	"github.com/cetteup/joinme.click-launcher/pkg/version_detector"
+)
+
+func buildGameRouter(fileRepository *filerepo.FileRepository, launchHistory *history.History, dryRun bool) *router.GameRouter {
+	// There is no native registry on Linux, so the finder does not need a registry repository
+	gameFinder := software_finder.New(nil, fileRepository)
+	osFileRepository := game_launcher.NewOSFileRepository(fileRepository)
+	gameLauncher := game_launcher.New(osFileRepository, game_launcher.NewExecProcessRunner(), dryRun)
+	versionDetector := version_detector.New(fileRepository)
+	serverQuery := server_query.New(serverQueryTimeout)
+	// Handlers are registered using XDG desktop entries, which are just files
+	return router.New(osFileRepository, gameFinder, gameLauncher, versionDetector, serverQuery, net.DefaultResolver, launchHistory)
+}
@@ -0,0 +1,214 @@
+//go:build unit
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectCommand(t *testing.T) {
+	type test struct {
+		name            string
+		givenArgs       []string
+		givenVersion    bool
+		givenDeregister bool
+		expectedCommand string
+		expectedArgs    []string
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name:            "selects register command without args",
+			givenArgs:       nil,
+			expectedCommand: registerCommand,
+			expectedArgs:    nil,
+		},
+		{
+			name:            "selects register command",
+			givenArgs:       []string{"register", "bf2", "cod4"},
+			expectedCommand: registerCommand,
+			expectedArgs:    []string{"bf2", "cod4"},
+		},
+		{
+			name:            "selects deregister command",
+			givenArgs:       []string{"deregister", "bf2"},
+			expectedCommand: deregisterCommand,
+			expectedArgs:    []string{"bf2"},
+		},
+		{
+			name:            "selects launch command",
+			givenArgs:       []string{"launch", "bf2://127.0.0.1:16567"},
+			expectedCommand: launchCommand,
+			expectedArgs:    []string{"bf2://127.0.0.1:16567"},
+		},
+		{
+			name:            "selects status command",
+			givenArgs:       []string{"status"},
+			expectedCommand: statusCommand,
+			expectedArgs:    []string{},
+		},
+		{
+			name:            "selects doctor command",
+			givenArgs:       []string{"doctor", "cod4"},
+			expectedCommand: doctorCommand,
+			expectedArgs:    []string{"cod4"},
+		},
+		{
+			name:            "selects list command",
+			givenArgs:       []string{"list"},
+			expectedCommand: listCommand,
+			expectedArgs:    []string{},
+		},
+		{
+			name:            "selects history command",
+			givenArgs:       []string{"history", "-limit", "5"},
+			expectedCommand: historyCommand,
+			expectedArgs:    []string{"-limit", "5"},
+		},
+		{
+			name:            "selects version command",
+			givenArgs:       []string{"version"},
+			expectedCommand: versionCommand,
+			expectedArgs:    []string{},
+		},
+		{
+			name:            "selects launch command for single URL arg",
+			givenArgs:       []string{"bf2://127.0.0.1:16567"},
+			expectedCommand: launchCommand,
+			expectedArgs:    []string{"bf2://127.0.0.1:16567"},
+		},
+		{
+			name:            "selects version command for legacy version flag",
+			givenArgs:       []string{"bf2://127.0.0.1:16567"},
+			givenVersion:    true,
+			expectedCommand: versionCommand,
+			expectedArgs:    nil,
+		},
+		{
+			name:            "selects deregister command for legacy deregister flag",
+			givenArgs:       nil,
+			givenDeregister: true,
+			expectedCommand: deregisterCommand,
+			expectedArgs:    nil,
+		},
+		{
+			name:            "selects deregister command for legacy deregister flag passing args",
+			givenArgs:       []string{"bf2", "cod4"},
+			givenDeregister: true,
+			expectedCommand: deregisterCommand,
+			expectedArgs:    []string{"bf2", "cod4"},
+		},
+		{
+			name:            "error for unknown command",
+			givenArgs:       []string{"unregister", "bf2"},
+			wantErrContains: "unknown command: unregister",
+		},
+		{
+			name:            "error for single arg which is not a URL",
+			givenArgs:       []string{"bf2"},
+			wantErrContains: "unknown command: bf2",
+		},
+		{
+			name:            "error for multiple URL args",
+			givenArgs:       []string{"bf2://127.0.0.1:16567", "cod4://127.0.0.1:28960"},
+			wantErrContains: "unknown command: bf2://127.0.0.1:16567",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			commands := makeCommands(&options{})
+
+			// WHEN
+			cmd, args, err := selectCommand(commands, tt.givenArgs, tt.givenVersion, tt.givenDeregister)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCommand, cmd.name)
+				assert.Equal(t, tt.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestFindCommand(t *testing.T) {
+	type test struct {
+		name            string
+		givenName       string
+		expectedCommand string
+		wantPanic       bool
+	}
+
+	tests := []test{
+		{
+			name:            "finds register command",
+			givenName:       registerCommand,
+			expectedCommand: registerCommand,
+		},
+		{
+			name:            "finds deregister command",
+			givenName:       deregisterCommand,
+			expectedCommand: deregisterCommand,
+		},
+		{
+			name:            "finds launch command",
+			givenName:       launchCommand,
+			expectedCommand: launchCommand,
+		},
+		{
+			name:            "finds status command",
+			givenName:       statusCommand,
+			expectedCommand: statusCommand,
+		},
+		{
+			name:            "finds doctor command",
+			givenName:       doctorCommand,
+			expectedCommand: doctorCommand,
+		},
+		{
+			name:            "finds list command",
+			givenName:       listCommand,
+			expectedCommand: listCommand,
+		},
+		{
+			name:            "finds history command",
+			givenName:       historyCommand,
+			expectedCommand: historyCommand,
+		},
+		{
+			name:            "finds version command",
+			givenName:       versionCommand,
+			expectedCommand: versionCommand,
+		},
+		{
+			name:      "panics for unknown command",
+			givenName: "unregister",
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			commands := makeCommands(&options{})
+
+			// WHEN/THEN
+			if tt.wantPanic {
+				assert.PanicsWithValue(t, "command not found: "+tt.givenName, func() {
+					findCommand(commands, tt.givenName)
+				})
+			} else {
+				cmd := findCommand(commands, tt.givenName)
+				assert.Equal(t, tt.expectedCommand, cmd.name)
+			}
+		})
+	}
+}
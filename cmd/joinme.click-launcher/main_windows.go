@@ -0,0 +1,25 @@
+package main
+
+import (
+	"net"
+
+	filerepo "github.com/cetteup/filerepo/pkg"
+
+	"github.com/cetteup/joinme.click-launcher/internal/history"
+	"github.com/cetteup/joinme.click-launcher/internal/router"
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/registry_repository"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
+	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+	"github.com/cetteup/joinme.click-launcher/pkg/version_detector"
+)
+
+func buildGameRouter(fileRepository *filerepo.FileRepository, launchHistory *history.History, dryRun bool) *router.GameRouter {
+	registryRepository := registry_repository.New()
+
+	gameFinder := software_finder.New(registryRepository, fileRepository)
+	gameLauncher := game_launcher.New(game_launcher.NewOSFileRepository(fileRepository), game_launcher.NewExecProcessRunner(), dryRun)
+	versionDetector := version_detector.New(fileRepository)
+	serverQuery := server_query.New(serverQueryTimeout)
+	return router.New(registryRepository, gameFinder, gameLauncher, versionDetector, serverQuery, net.DefaultResolver, launchHistory)
+}
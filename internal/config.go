@@ -2,8 +2,10 @@ package internal
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -12,12 +14,17 @@ import (
 
 const (
 	ConfigFilename = "config.yaml"
+	ReportsDirName = "reports"
+
+	reportDirTimeFormat = "20060102-150405"
 )
 
 type config struct {
-	DebugLogging bool                            `yaml:"debug_logging"`
-	QuietLaunch  bool                            `yaml:"quiet_launch"`
-	Games        map[string]CustomLauncherConfig `yaml:"games"`
+	DebugLogging    bool                            `yaml:"debug_logging"`
+	QuietLaunch     bool                            `yaml:"quiet_launch"`
+	WinePrefixes    []string                        `yaml:"wine_prefixes"`
+	GOGLibraryPaths []string                        `yaml:"gog_library_paths"`
+	Games           map[string]CustomLauncherConfig `yaml:"games"`
 }
 
 func (c *config) GetCustomLauncherConfig(game string) *CustomLauncherConfig {
@@ -32,8 +39,23 @@ type CustomLauncherConfig struct {
 	ExecutableName string             `yaml:"executable_name"`
 	ExecutablePath string             `yaml:"executable_path"`
 	InstallPath    string             `yaml:"install_path"`
+	WinePrefix     string             `yaml:"wine_prefix"`
+	Wrapper        []string           `yaml:"wrapper"`
+	Env            map[string]string  `yaml:"env"`
 	Args           []string           `yaml:"args"`
 	Hooks          []CustomHookConfig `yaml:"hooks"`
+	MinimumVersion string             `yaml:"minimum_version"`
+	// Pointers, since titles may enable aborting by default
+	AbortIfServerUnreachable *bool                    `yaml:"abort_if_server_unreachable"`
+	AbortIfServerFull        *bool                    `yaml:"abort_if_server_full"`
+	WaitForExit              bool                     `yaml:"wait_for_exit"`
+	CrashPolicy              *CustomCrashPolicyConfig `yaml:"crash_policy"`
+	LaunchReport             bool                     `yaml:"launch_report"`
+}
+
+type CustomCrashPolicyConfig struct {
+	MaxRetries *int          `yaml:"max_retries"`
+	MinRuntime time.Duration `yaml:"min_runtime"`
 }
 
 type CustomHookConfig struct {
@@ -44,7 +66,7 @@ type CustomHookConfig struct {
 }
 
 func (c *CustomLauncherConfig) HasValues() bool {
-	return c != nil && (c.HasExecutableName() || c.HasExecutablePath() || c.HasInstallPath() || c.HasArgs() || c.HasHookConfigs())
+	return c != nil && (c.HasExecutableName() || c.HasExecutablePath() || c.HasInstallPath() || c.HasWinePrefix() || c.HasWrapper() || c.HasEnv() || c.HasArgs() || c.HasHookConfigs() || c.HasMinimumVersion() || c.HasAbortIfServerUnreachable() || c.HasAbortIfServerFull() || c.HasWaitForExit() || c.HasCrashPolicy() || c.HasLaunchReport())
 }
 
 func (c *CustomLauncherConfig) HasExecutableName() bool {
@@ -59,6 +81,18 @@ func (c *CustomLauncherConfig) HasInstallPath() bool {
 	return c != nil && c.InstallPath != ""
 }
 
+func (c *CustomLauncherConfig) HasWinePrefix() bool {
+	return c != nil && c.WinePrefix != ""
+}
+
+func (c *CustomLauncherConfig) HasWrapper() bool {
+	return c != nil && len(c.Wrapper) > 0
+}
+
+func (c *CustomLauncherConfig) HasEnv() bool {
+	return c != nil && len(c.Env) > 0
+}
+
 func (c *CustomLauncherConfig) HasArgs() bool {
 	return c != nil && len(c.Args) > 0
 }
@@ -67,16 +101,45 @@ func (c *CustomLauncherConfig) HasHookConfigs() bool {
 	return c != nil && len(c.Hooks) > 0
 }
 
-func LoadConfig() error {
-	wd, err := os.Executable()
-	if err != nil {
-		return err
+func (c *CustomLauncherConfig) HasMinimumVersion() bool {
+	return c != nil && c.MinimumVersion != ""
+}
+
+func (c *CustomLauncherConfig) HasAbortIfServerUnreachable() bool {
+	return c != nil && c.AbortIfServerUnreachable != nil
+}
+
+func (c *CustomLauncherConfig) HasAbortIfServerFull() bool {
+	return c != nil && c.AbortIfServerFull != nil
+}
+
+func (c *CustomLauncherConfig) HasWaitForExit() bool {
+	return c != nil && c.WaitForExit
+}
+
+func (c *CustomLauncherConfig) HasCrashPolicy() bool {
+	return c != nil && c.CrashPolicy != nil
+}
+
+func (c *CustomLauncherConfig) HasLaunchReport() bool {
+	return c != nil && c.LaunchReport
+}
+
+// LoadConfig Loads the config from the given path, falling back to the config file next to the launcher executable
+// if no path is given (which, unlike an explicitly given one, does not need to exist)
+func LoadConfig(path string) error {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		path, err = BuildConfigFilePath()
+		if err != nil {
+			return err
+		}
 	}
 
-	path := filepath.Join(filepath.Dir(wd), ConfigFilename)
 	content, err := os.ReadFile(path)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
 			return nil
 		}
 		return err
@@ -90,4 +153,26 @@ func LoadConfig() error {
 	return nil
 }
 
+// BuildConfigFilePath Returns the path of the default config file, located next to the launcher executable
+func BuildConfigFilePath() (string, error) {
+	wd, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(wd), ConfigFilename), nil
+}
+
+// BuildLaunchReportDirPath Returns the path of the report dir for a single launch of the given game
+// (located next to the launcher executable, just like the config file)
+func BuildLaunchReportDirPath(game string, launchedAt time.Time) (string, error) {
+	wd, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%s", game, launchedAt.Format(reportDirTimeFormat))
+	return filepath.Join(filepath.Dir(wd), ReportsDirName, name), nil
+}
+
 var Config = &config{}
@@ -6,11 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/cetteup/joinme.click-launcher/internal/testhelpers"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
 )
 
@@ -19,14 +21,21 @@ func TestLoadConfig(t *testing.T) {
 		// GIVEN
 		Config = &config{}
 		givenConfig := config{
-			DebugLogging: true,
-			QuietLaunch:  true,
+			DebugLogging:    true,
+			QuietLaunch:     true,
+			WinePrefixes:    []string{"/home/user/.wine"},
+			GOGLibraryPaths: []string{"/home/user/GOG Games"},
 			Games: map[string]CustomLauncherConfig{
 				"some-game": {
 					ExecutableName: "game.exe",
 					ExecutablePath: "bin",
 					InstallPath:    "C:\\Games\\SomeGame",
-					Args:           []string{"+fullscreen", "0"},
+					WinePrefix:     "/home/user/Games/some-game",
+					Wrapper:        []string{"gamemoderun", "wine"},
+					Env: map[string]string{
+						"WINEDLLOVERRIDES": "d3d9=n,b",
+					},
+					Args: []string{"+fullscreen", "0"},
 					Hooks: []CustomHookConfig{
 						{
 							Handler:     "some-handler",
@@ -37,12 +46,21 @@ func TestLoadConfig(t *testing.T) {
 							},
 						},
 					},
+					MinimumVersion:           "1.5",
+					AbortIfServerUnreachable: testhelpers.Ptr(true),
+					AbortIfServerFull:        testhelpers.Ptr(false),
+					WaitForExit:              true,
+					CrashPolicy: &CustomCrashPolicyConfig{
+						MaxRetries: testhelpers.Ptr(1),
+						MinRuntime: 10 * time.Second,
+					},
+					LaunchReport: true,
 				},
 			},
 		}
 		content, err := yaml.Marshal(givenConfig)
 		require.NoError(t, err)
-		configFilePath, err := buildConfigFilePath()
+		configFilePath, err := BuildConfigFilePath()
 		require.NoError(t, err)
 		err = writeConfigFile(configFilePath, content)
 		require.NoError(t, err)
@@ -52,7 +70,7 @@ func TestLoadConfig(t *testing.T) {
 		})
 
 		// WHEN
-		err = LoadConfig()
+		err = LoadConfig("")
 		require.NoError(t, err)
 
 		// THEN
@@ -64,7 +82,7 @@ func TestLoadConfig(t *testing.T) {
 		Config = &config{}
 
 		// WHEN
-		err := LoadConfig()
+		err := LoadConfig("")
 
 		// THEN
 		require.NoError(t, err)
@@ -74,7 +92,7 @@ func TestLoadConfig(t *testing.T) {
 	t.Run("error if config file contains invalid yaml", func(t *testing.T) {
 		// GIVEN
 		Config = &config{}
-		configFilePath, err := buildConfigFilePath()
+		configFilePath, err := BuildConfigFilePath()
 		require.NoError(t, err)
 		err = writeConfigFile(configFilePath, []byte("this-is-not-valid-yaml"))
 		require.NoError(t, err)
@@ -84,12 +102,39 @@ func TestLoadConfig(t *testing.T) {
 		})
 
 		// WHEN
-		err = LoadConfig()
+		err = LoadConfig("")
 
 		// THEN
 		require.ErrorContains(t, err, "cannot unmarshal")
 		assert.Equal(t, &config{}, Config)
 	})
+
+	t.Run("successfully loads config from given path", func(t *testing.T) {
+		// GIVEN
+		Config = &config{}
+		configFilePath := filepath.Join(t.TempDir(), "custom.yaml")
+		err := writeConfigFile(configFilePath, []byte("quiet_launch: true\n"))
+		require.NoError(t, err)
+
+		// WHEN
+		err = LoadConfig(configFilePath)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &config{QuietLaunch: true}, Config)
+	})
+
+	t.Run("error if given config file does not exist", func(t *testing.T) {
+		// GIVEN
+		Config = &config{}
+
+		// WHEN
+		err := LoadConfig(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+
+		// THEN
+		require.ErrorIs(t, err, os.ErrNotExist)
+		assert.Equal(t, &config{}, Config)
+	})
 }
 
 func TestConfig_GetCustomLauncherConfig(t *testing.T) {
@@ -206,6 +251,29 @@ func TestCustomLauncherConfig_HasValues(t *testing.T) {
 			},
 			wantHasValues: true,
 		},
+		{
+			name: "true for config with wine prefix only",
+			givenConfig: &CustomLauncherConfig{
+				WinePrefix: "/home/user/.wine",
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with wrapper only",
+			givenConfig: &CustomLauncherConfig{
+				Wrapper: []string{"gamemoderun", "wine"},
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with env only",
+			givenConfig: &CustomLauncherConfig{
+				Env: map[string]string{
+					"WINEPREFIX": "/home/user/.wine",
+				},
+			},
+			wantHasValues: true,
+		},
 		{
 			name: "true for config with args only",
 			givenConfig: &CustomLauncherConfig{
@@ -225,6 +293,48 @@ func TestCustomLauncherConfig_HasValues(t *testing.T) {
 			},
 			wantHasValues: true,
 		},
+		{
+			name: "true for config with minimum version only",
+			givenConfig: &CustomLauncherConfig{
+				MinimumVersion: "1.5",
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with abort if server unreachable only",
+			givenConfig: &CustomLauncherConfig{
+				AbortIfServerUnreachable: testhelpers.Ptr(false),
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with abort if server full only",
+			givenConfig: &CustomLauncherConfig{
+				AbortIfServerFull: testhelpers.Ptr(true),
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with wait for exit only",
+			givenConfig: &CustomLauncherConfig{
+				WaitForExit: true,
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with crash policy only",
+			givenConfig: &CustomLauncherConfig{
+				CrashPolicy: &CustomCrashPolicyConfig{},
+			},
+			wantHasValues: true,
+		},
+		{
+			name: "true for config with launch report only",
+			givenConfig: &CustomLauncherConfig{
+				LaunchReport: true,
+			},
+			wantHasValues: true,
+		},
 		{
 			name:          "false for empty config",
 			givenConfig:   &CustomLauncherConfig{},
@@ -248,13 +358,19 @@ func TestCustomLauncherConfig_HasValues(t *testing.T) {
 	}
 }
 
-func buildConfigFilePath() (string, error) {
-	wd, err := os.Executable()
-	if err != nil {
-		return "", err
-	}
+func TestBuildLaunchReportDirPath(t *testing.T) {
+	t.Run("builds report dir path next to executable", func(t *testing.T) {
+		// GIVEN
+		executable, err := os.Executable()
+		require.NoError(t, err)
 
-	return filepath.Join(filepath.Dir(wd), ConfigFilename), nil
+		// WHEN
+		path, err := BuildLaunchReportDirPath("bf2", time.Date(2024, 3, 1, 18, 4, 5, 0, time.UTC))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(filepath.Dir(executable), "reports", "bf2-20240301-180405"), path)
+	})
 }
 
 func writeConfigFile(path string, content []byte) error {
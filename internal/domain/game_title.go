@@ -2,14 +2,37 @@ package domain
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strings"
 
 	"github.com/cetteup/joinme.click-launcher/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
+const (
+	actionNameLaunch = "launch"
+)
+
+// GameAction Action a game supports via action URLs, e.g. bf4://act/spectate?server=<game id>
+type GameAction struct {
+	// Name of the action, used as action URL path (e.g. "spectate" for <game>://act/spectate)
+	Name       string
+	LaunchType game_launcher.LaunchType
+	// Validates the action URL's parameters (nil if the action does not take any parameters)
+	URLValidator game_launcher.URLValidator
+	// Derives the URL of the server to join from the action URL (nil if the action does not involve joining a server)
+	ServerURLBuilder func(u *url.URL) *url.URL
+}
+
+// ActionLaunch Launches the game without joining any server, supported by any game
+var ActionLaunch = GameAction{
+	Name:       actionNameLaunch,
+	LaunchType: game_launcher.LaunchTypeLaunchOnly,
+}
+
 type GameTitle struct {
 	Name           string
 	ProtocolScheme string
@@ -20,6 +43,26 @@ type GameTitle struct {
 	URLValidator   game_launcher.URLValidator
 	CmdBuilder     game_launcher.CommandBuilder
 	HookHandlers   []game_launcher.HookHandler
+	// Version (patch) the game needs to be updated to in order to be launched (empty if any version is fine)
+	MinimumVersion string
+	// Config for querying servers before joining them (nil if the game's servers cannot be queried)
+	QueryConfig *server_query.Config
+	// Builds any additional arguments required to join a server based on its query results (nil if none are required)
+	ServerArgsBuilder ServerArgsBuilder
+	// Whether to abort joining a server if it cannot be queried (rather than launching the game anyway)
+	AbortIfServerUnreachable bool
+	// Whether to abort joining a server if the query shows it is full
+	AbortIfServerFull bool
+	// Actions the game supports in addition to launching it (act/launch)
+	Actions []GameAction
+	// Log files written by the game, relative to the install path (glob patterns, dirs are collected including any files)
+	LogFiles []string
+	// Whether to capture the game's output and collect its log files into a per-launch report dir
+	LaunchReport bool
+}
+
+type ServerArgsBuilder interface {
+	GetServerArgs(info *server_query.ServerInfo) []string
 }
 
 func (t *GameTitle) AddCustomConfig(config internal.CustomLauncherConfig) {
@@ -42,26 +85,164 @@ func (t *GameTitle) AddCustomConfig(config internal.CustomLauncherConfig) {
 		}, t.FinderConfigs...)
 	}
 
+	if config.HasWinePrefix() {
+		// Prepend custom prefix based finders in order to search the custom prefix before any default/global ones
+		t.addWinePrefixConfigs([]string{config.WinePrefix}, true)
+	}
+
+	if config.HasWrapper() {
+		t.LauncherConfig.Wrapper = config.Wrapper
+	}
+
+	if config.HasEnv() {
+		// Copy any existing env, since the underlying map is shared with the (global) title definition
+		env := make(map[string]string, len(t.LauncherConfig.Env)+len(config.Env))
+		for key, value := range t.LauncherConfig.Env {
+			env[key] = value
+		}
+		for key, value := range config.Env {
+			env[key] = value
+		}
+		t.LauncherConfig.Env = env
+	}
+
 	if config.HasArgs() {
 		t.LauncherConfig.DefaultArgs = append(t.LauncherConfig.DefaultArgs, config.Args...)
 	}
 
+	if config.HasMinimumVersion() {
+		t.MinimumVersion = config.MinimumVersion
+	}
+
+	if config.HasAbortIfServerUnreachable() {
+		t.AbortIfServerUnreachable = *config.AbortIfServerUnreachable
+	}
+
+	if config.HasAbortIfServerFull() {
+		t.AbortIfServerFull = *config.AbortIfServerFull
+	}
+
+	if config.HasWaitForExit() {
+		t.LauncherConfig.WaitForExit = config.WaitForExit
+	}
+
+	if config.HasLaunchReport() {
+		t.LaunchReport = true
+		// Log files can only be collected once the game exited
+		t.LauncherConfig.WaitForExit = true
+	}
+
+	if config.HasCrashPolicy() {
+		policy := &game_launcher.CrashPolicy{
+			MinRuntime: config.CrashPolicy.MinRuntime,
+			MaxRetries: game_launcher.DefaultCrashMaxRetries,
+		}
+		if policy.MinRuntime == 0 {
+			policy.MinRuntime = game_launcher.DefaultCrashMinRuntime
+		}
+		// Explicitly setting max retries to 0 detects (and logs) crashes without relaunching the game
+		if config.CrashPolicy.MaxRetries != nil {
+			policy.MaxRetries = *config.CrashPolicy.MaxRetries
+		}
+		t.LauncherConfig.CrashPolicy = policy
+		// Crashes can only be detected when waiting for the game to exit
+		t.LauncherConfig.WaitForExit = true
+	}
+
 	if config.HasHookConfigs() {
+		// Copy default hooks rather than modifying the shared slice (or the args maps within it)
+		hookConfigs := make([]game_launcher.HookConfig, len(t.LauncherConfig.HookConfigs), len(t.LauncherConfig.HookConfigs)+len(config.Hooks))
+		copy(hookConfigs, t.LauncherConfig.HookConfigs)
 		for _, hook := range config.Hooks {
-			t.LauncherConfig.HookConfigs = append(t.LauncherConfig.HookConfigs, game_launcher.HookConfig{
+			// Custom hook configs for the same handler and phase as a default config are merged into the default
+			// (e.g. to pass args to the default kill-process hook), keeping any other default settings
+			if mergeCustomHookConfig(hookConfigs, hook) {
+				continue
+			}
+			hookConfigs = append(hookConfigs, game_launcher.HookConfig{
 				Handler:     hook.Handler,
 				When:        hook.When,
 				ExitOnError: hook.ExitOnError,
 				Args:        hook.Args,
 			})
 		}
+		t.LauncherConfig.HookConfigs = hookConfigs
+	}
+}
+
+// mergeCustomHookConfig Merges the custom hook config into any (default) configs with the same handler and phase,
+// with custom args taking precedence over default args. Returns whether any config was merged into.
+func mergeCustomHookConfig(hookConfigs []game_launcher.HookConfig, hook internal.CustomHookConfig) bool {
+	var merged bool
+	for i, hc := range hookConfigs {
+		if hc.Handler != hook.Handler || hc.When != hook.When {
+			continue
+		}
+
+		args := make(map[string]string, len(hc.Args)+len(hook.Args))
+		for key, value := range hc.Args {
+			args[key] = value
+		}
+		for key, value := range hook.Args {
+			args[key] = value
+		}
+		hookConfigs[i].Args = args
+		hookConfigs[i].ExitOnError = hc.ExitOnError || hook.ExitOnError
+		merged = true
 	}
+	return merged
+}
+
+// AddWinePrefixes Adds Wine prefix based finders for any registry based finders of the game and its mods
+func (t *GameTitle) AddWinePrefixes(prefixes ...string) {
+	t.addWinePrefixConfigs(prefixes, false)
+}
+
+func (t *GameTitle) addWinePrefixConfigs(prefixes []string, prepend bool) {
+	if prepend {
+		t.FinderConfigs = append(software_finder.MakeWinePrefixConfigs(t.FinderConfigs, prefixes...), t.FinderConfigs...)
+	} else {
+		t.FinderConfigs = append(t.FinderConfigs, software_finder.MakeWinePrefixConfigs(t.FinderConfigs, prefixes...)...)
+	}
+
+	// Copy mods, since the underlying array is shared with the (global) title definition
+	mods := make([]GameMod, len(t.Mods))
+	copy(mods, t.Mods)
+	for i, mod := range mods {
+		wineConfigs := software_finder.MakeWinePrefixConfigs(mod.finderConfigs, prefixes...)
+		if prepend {
+			mods[i].finderConfigs = append(wineConfigs, mod.finderConfigs...)
+		} else {
+			mods[i].finderConfigs = append(append([]software_finder.Config{}, mod.finderConfigs...), wineConfigs...)
+		}
+	}
+	t.Mods = mods
+}
+
+// AddGOGLibraries Adds GOG library based finders for any GOG finders of the game
+func (t *GameTitle) AddGOGLibraries(libraryPaths ...string) {
+	t.FinderConfigs = append(t.FinderConfigs, software_finder.MakeGOGLibraryConfigs(t.FinderConfigs, libraryPaths...)...)
 }
 
 func (t *GameTitle) RequiresPlatformClient() bool {
 	return t.PlatformClient != nil
 }
 
+// GetAction Returns the action with the given name, falling back to the launch action supported by any game
+func (t *GameTitle) GetAction(name string) *GameAction {
+	for _, action := range t.Actions {
+		if action.Name == name {
+			return &action
+		}
+	}
+	if name == ActionLaunch.Name {
+		// Return a copy, since the launch action is shared by all games
+		action := ActionLaunch
+		return &action
+	}
+	return nil
+}
+
 func (t *GameTitle) GetMod(slug string) *GameMod {
 	for _, mod := range t.Mods {
 		if strings.EqualFold(slug, mod.Slug) {
@@ -99,7 +280,8 @@ func (m *GameMod) ComputeFinderConfigs(gameInstallPath string) []software_finder
 	for _, config := range m.finderConfigs {
 		// Config is not a pointer, so we can change "it" and the function call remains idempotent
 		if config.ForType == software_finder.PathFinder {
-			config.InstallPath = filepath.Join(gameInstallPath, config.InstallPath)
+			// Relative paths are defined with Windows separators, so convert them to whatever the host uses
+			config.InstallPath = filepath.Join(gameInstallPath, filepath.FromSlash(strings.ReplaceAll(config.InstallPath, "\\", "/")))
 		}
 		computedConfigs = append(computedConfigs, config)
 	}
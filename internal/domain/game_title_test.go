@@ -4,10 +4,12 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/cetteup/joinme.click-launcher/internal"
+	"github.com/cetteup/joinme.click-launcher/internal/testhelpers"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
@@ -146,6 +148,180 @@ func TestGameTitle_AddCustomConfig(t *testing.T) {
 				assert.Equal(t, givenTitle.FinderConfigs, title.FinderConfigs)
 			},
 		},
+		{
+			name: "merges custom hook into default hook for same handler and phase",
+			givenConfig: internal.CustomLauncherConfig{
+				Hooks: []internal.CustomHookConfig{
+					{
+						Handler: "some-default-handler",
+						When:    game_launcher.HookWhenAlways,
+						Args: map[string]string{
+							"some-key":         "some-value",
+							"some-default-key": "some-custom-value",
+						},
+					},
+				},
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, []game_launcher.HookConfig{
+					{
+						Handler:     "some-default-handler",
+						When:        game_launcher.HookWhenAlways,
+						ExitOnError: true,
+						Args: map[string]string{
+							"some-key":          "some-value",
+							"some-default-key":  "some-custom-value",
+							"other-default-key": "other-default-value",
+						},
+					},
+				}, title.LauncherConfig.HookConfigs)
+				// Original hooks should not have been changed
+				assert.Equal(t, []game_launcher.HookConfig{
+					{
+						Handler:     "some-default-handler",
+						When:        game_launcher.HookWhenAlways,
+						ExitOnError: true,
+						Args: map[string]string{
+							"some-default-key":  "some-default-value",
+							"other-default-key": "other-default-value",
+						},
+					},
+				}, givenTitle.LauncherConfig.HookConfigs)
+			},
+		},
+		{
+			name: "adds custom hook for same handler but different phase",
+			givenConfig: internal.CustomLauncherConfig{
+				Hooks: []internal.CustomHookConfig{
+					{
+						Handler: "some-default-handler",
+						When:    game_launcher.HookWhenPostExit,
+					},
+				},
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, append(givenTitle.LauncherConfig.HookConfigs, game_launcher.HookConfig{
+					Handler: "some-default-handler",
+					When:    game_launcher.HookWhenPostExit,
+				}), title.LauncherConfig.HookConfigs)
+			},
+		},
+		{
+			name: "successfully adds wine prefix only",
+			givenConfig: internal.CustomLauncherConfig{
+				WinePrefix: "/home/user/.wine",
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, givenTitle.LauncherConfig, title.LauncherConfig)
+				assert.Equal(t, append([]software_finder.Config{
+					{
+						ForType:           software_finder.WinePrefixFinder,
+						RegistryKey:       software_finder.RegistryKeyLocalMachine,
+						RegistryPath:      "default",
+						RegistryValueName: "default",
+						WinePrefix:        givenConfig.WinePrefix,
+					},
+				}, givenTitle.FinderConfigs...), title.FinderConfigs)
+			},
+		},
+		{
+			name: "successfully adds wrapper and env only",
+			givenConfig: internal.CustomLauncherConfig{
+				Wrapper: []string{"gamemoderun", "wine"},
+				Env: map[string]string{
+					"WINEPREFIX": "/home/user/.wine",
+				},
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, givenConfig.Wrapper, title.LauncherConfig.Wrapper)
+				assert.Equal(t, map[string]string{
+					"DEFAULT":    "default",
+					"WINEPREFIX": "/home/user/.wine",
+				}, title.LauncherConfig.Env)
+				// Original env should not have been changed
+				assert.Equal(t, map[string]string{"DEFAULT": "default"}, givenTitle.LauncherConfig.Env)
+				assert.Equal(t, givenTitle.LauncherConfig.DefaultArgs, title.LauncherConfig.DefaultArgs)
+				assert.Equal(t, givenTitle.FinderConfigs, title.FinderConfigs)
+			},
+		},
+		{
+			name: "successfully adds minimum version only",
+			givenConfig: internal.CustomLauncherConfig{
+				MinimumVersion: "1.5",
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, givenConfig.MinimumVersion, title.MinimumVersion)
+				assert.Equal(t, givenTitle.LauncherConfig, title.LauncherConfig)
+				assert.Equal(t, givenTitle.FinderConfigs, title.FinderConfigs)
+			},
+		},
+		{
+			name: "successfully adds server abort options only",
+			givenConfig: internal.CustomLauncherConfig{
+				AbortIfServerUnreachable: testhelpers.Ptr(true),
+				AbortIfServerFull:        testhelpers.Ptr(true),
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.True(t, title.AbortIfServerUnreachable)
+				assert.True(t, title.AbortIfServerFull)
+				assert.Equal(t, givenTitle.LauncherConfig, title.LauncherConfig)
+				assert.Equal(t, givenTitle.FinderConfigs, title.FinderConfigs)
+			},
+		},
+		{
+			name: "successfully adds wait for exit only",
+			givenConfig: internal.CustomLauncherConfig{
+				WaitForExit: true,
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.True(t, title.LauncherConfig.WaitForExit)
+				assert.Equal(t, givenTitle.LauncherConfig.HookConfigs, title.LauncherConfig.HookConfigs)
+				assert.Equal(t, givenTitle.FinderConfigs, title.FinderConfigs)
+			},
+		},
+		{
+			name: "successfully adds crash policy only",
+			givenConfig: internal.CustomLauncherConfig{
+				CrashPolicy: &internal.CustomCrashPolicyConfig{
+					MaxRetries: testhelpers.Ptr(0),
+				},
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, &game_launcher.CrashPolicy{
+					MinRuntime: game_launcher.DefaultCrashMinRuntime,
+					MaxRetries: 0,
+				}, title.LauncherConfig.CrashPolicy)
+				// Crash detection requires supervised mode
+				assert.True(t, title.LauncherConfig.WaitForExit)
+				assert.Nil(t, givenTitle.LauncherConfig.CrashPolicy)
+			},
+		},
+		{
+			name: "successfully adds crash policy with default max retries",
+			givenConfig: internal.CustomLauncherConfig{
+				CrashPolicy: &internal.CustomCrashPolicyConfig{
+					MinRuntime: 10 * time.Second,
+				},
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.Equal(t, &game_launcher.CrashPolicy{
+					MinRuntime: 10 * time.Second,
+					MaxRetries: game_launcher.DefaultCrashMaxRetries,
+				}, title.LauncherConfig.CrashPolicy)
+			},
+		},
+		{
+			name: "successfully adds launch report only",
+			givenConfig: internal.CustomLauncherConfig{
+				LaunchReport: true,
+			},
+			expect: func(t *testing.T, givenTitle GameTitle, givenConfig internal.CustomLauncherConfig, title GameTitle) {
+				assert.True(t, title.LaunchReport)
+				// Collecting log files requires supervised mode
+				assert.True(t, title.LauncherConfig.WaitForExit)
+				assert.Equal(t, givenTitle.LauncherConfig.HookConfigs, title.LauncherConfig.HookConfigs)
+			},
+		},
 		{
 			name:        "does not change config if custom config is empty",
 			givenConfig: internal.CustomLauncherConfig{},
@@ -173,10 +349,18 @@ func TestGameTitle_AddCustomConfig(t *testing.T) {
 					ExecutableName: "default.exe",
 					ExecutablePath: "default-path",
 					DefaultArgs:    []string{"+default"},
+					Env: map[string]string{
+						"DEFAULT": "default",
+					},
 					HookConfigs: []game_launcher.HookConfig{
 						{
-							Handler: "some-default-handler",
-							When:    game_launcher.HookWhenAlways,
+							Handler:     "some-default-handler",
+							When:        game_launcher.HookWhenAlways,
+							ExitOnError: true,
+							Args: map[string]string{
+								"some-default-key":  "some-default-value",
+								"other-default-key": "other-default-value",
+							},
 						},
 					},
 				},
@@ -199,6 +383,70 @@ func TestGameTitle_AddCustomConfig(t *testing.T) {
 	}
 }
 
+func TestGameTitle_AddWinePrefixes(t *testing.T) {
+	// GIVEN
+	registryConfig := software_finder.Config{
+		ForType:           software_finder.RegistryFinder,
+		RegistryKey:       software_finder.RegistryKeyLocalMachine,
+		RegistryPath:      "SOFTWARE\\some\\game",
+		RegistryValueName: "InstallDir",
+	}
+	pathConfig := software_finder.Config{
+		ForType:     software_finder.PathFinder,
+		InstallPath: "mods\\some-mod",
+		PathType:    software_finder.PathTypeDir,
+	}
+	givenTitle := GameTitle{
+		FinderConfigs: []software_finder.Config{registryConfig},
+		Mods: []GameMod{
+			MakeMod("Some mod", "some-mod", []software_finder.Config{registryConfig, pathConfig}),
+		},
+	}
+	title := givenTitle
+
+	// WHEN
+	title.AddWinePrefixes("/prefix/a", "/prefix/b")
+
+	// THEN
+	wineConfigA, wineConfigB := registryConfig, registryConfig
+	wineConfigA.ForType, wineConfigA.WinePrefix = software_finder.WinePrefixFinder, "/prefix/a"
+	wineConfigB.ForType, wineConfigB.WinePrefix = software_finder.WinePrefixFinder, "/prefix/b"
+	assert.Equal(t, []software_finder.Config{registryConfig, wineConfigA, wineConfigB}, title.FinderConfigs)
+	assert.Equal(t, []software_finder.Config{registryConfig, pathConfig, wineConfigA, wineConfigB}, title.Mods[0].finderConfigs)
+	// Original title (and its mods) should not have been changed
+	assert.Equal(t, []software_finder.Config{registryConfig}, givenTitle.FinderConfigs)
+	assert.Equal(t, []software_finder.Config{registryConfig, pathConfig}, givenTitle.Mods[0].finderConfigs)
+}
+
+func TestGameTitle_AddGOGLibraries(t *testing.T) {
+	// GIVEN
+	registryConfig := software_finder.Config{
+		ForType:           software_finder.RegistryFinder,
+		RegistryKey:       software_finder.RegistryKeyLocalMachine,
+		RegistryPath:      "SOFTWARE\\some\\game",
+		RegistryValueName: "InstallDir",
+	}
+	gogConfig := software_finder.Config{
+		ForType:   software_finder.GOGFinder,
+		GOGGameID: "1234",
+	}
+	givenTitle := GameTitle{
+		FinderConfigs: []software_finder.Config{registryConfig, gogConfig},
+	}
+	title := givenTitle
+
+	// WHEN
+	title.AddGOGLibraries("/library/a", "/library/b")
+
+	// THEN
+	libraryConfigA, libraryConfigB := gogConfig, gogConfig
+	libraryConfigA.InstallPath = "/library/a"
+	libraryConfigB.InstallPath = "/library/b"
+	assert.Equal(t, []software_finder.Config{registryConfig, gogConfig, libraryConfigA, libraryConfigB}, title.FinderConfigs)
+	// Original title should not have been changed
+	assert.Equal(t, []software_finder.Config{registryConfig, gogConfig}, givenTitle.FinderConfigs)
+}
+
 func TestGameTitle_RequiresPlatformClient(t *testing.T) {
 	type test struct {
 		name                       string
@@ -234,6 +482,54 @@ func TestGameTitle_RequiresPlatformClient(t *testing.T) {
 	}
 }
 
+func TestGameTitle_GetAction(t *testing.T) {
+	type test struct {
+		name       string
+		givenTitle GameTitle
+		givenName  string
+		wantAction *GameAction
+	}
+
+	spectate := GameAction{
+		Name:       "spectate",
+		LaunchType: game_launcher.LaunchTypeLaunchAndSpectate,
+	}
+	tests := []test{
+		{
+			name: "returns action declared by game title",
+			givenTitle: GameTitle{
+				Actions: []GameAction{spectate},
+			},
+			givenName:  "spectate",
+			wantAction: &spectate,
+		},
+		{
+			name:       "returns launch action for any game title",
+			givenTitle: GameTitle{},
+			givenName:  "launch",
+			wantAction: &ActionLaunch,
+		},
+		{
+			name: "returns nil for action not declared by game title",
+			givenTitle: GameTitle{
+				Actions: []GameAction{spectate},
+			},
+			givenName:  "command",
+			wantAction: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			action := tt.givenTitle.GetAction(tt.givenName)
+
+			// THEN
+			assert.Equal(t, tt.wantAction, action)
+		})
+	}
+}
+
 func TestGameTitle_GetMod(t *testing.T) {
 	type test struct {
 		name        string
@@ -342,74 +638,6 @@ func TestGameTitle_String(t *testing.T) {
 	}
 }
 
-func TestGameMod_ComputeFinderConfigs(t *testing.T) {
-	type test struct {
-		name                  string
-		givenMod              GameMod
-		givenGameInstallPath  string
-		expectedFinderConfigs []software_finder.Config
-	}
-
-	tests := []test{
-		{
-			name: "successfully computes finder configs",
-			givenMod: GameMod{
-				Name: "some-mod",
-				Slug: "some-mod-slug",
-				finderConfigs: []software_finder.Config{
-					{
-						ForType:     software_finder.PathFinder,
-						InstallPath: "mods\\some-mod\\objects.zip",
-						PathType:    software_finder.PathTypeFile,
-					},
-				},
-			},
-			givenGameInstallPath: "C:\\Games\\Battlefield",
-			expectedFinderConfigs: []software_finder.Config{
-				{
-					ForType:     software_finder.PathFinder,
-					InstallPath: "C:\\Games\\Battlefield\\mods\\some-mod\\objects.zip",
-					PathType:    software_finder.PathTypeFile,
-				},
-			},
-		},
-		{
-			name: "ignores finder configs not using path finder",
-			givenMod: GameMod{
-				Name: "some-mod",
-				Slug: "some-mod-slug",
-				finderConfigs: []software_finder.Config{
-					{
-						ForType:           software_finder.RegistryFinder,
-						RegistryKey:       software_finder.RegistryKeyLocalMachine,
-						RegistryPath:      "some-game\\some-mod",
-						RegistryValueName: "InstallPath",
-					},
-				},
-			},
-			givenGameInstallPath: "C:\\Games\\Battlefield",
-			expectedFinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "some-game\\some-mod",
-					RegistryValueName: "InstallPath",
-				},
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// WHEN
-			finderConfigs := tt.givenMod.ComputeFinderConfigs(tt.givenGameInstallPath)
-
-			// THEN
-			assert.Equal(t, tt.expectedFinderConfigs, finderConfigs)
-		})
-	}
-}
-
 func TestGameMod_String(t *testing.T) {
 	type test struct {
 		name           string
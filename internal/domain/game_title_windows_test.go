@@ -0,0 +1,79 @@
+//go:build unit
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+)
+
+func TestGameMod_ComputeFinderConfigs(t *testing.T) {
+	type test struct {
+		name                  string
+		givenMod              GameMod
+		givenGameInstallPath  string
+		expectedFinderConfigs []software_finder.Config
+	}
+
+	tests := []test{
+		{
+			name: "successfully computes finder configs",
+			givenMod: GameMod{
+				Name: "some-mod",
+				Slug: "some-mod-slug",
+				finderConfigs: []software_finder.Config{
+					{
+						ForType:     software_finder.PathFinder,
+						InstallPath: "mods\\some-mod\\objects.zip",
+						PathType:    software_finder.PathTypeFile,
+					},
+				},
+			},
+			givenGameInstallPath: "C:\\Games\\Battlefield",
+			expectedFinderConfigs: []software_finder.Config{
+				{
+					ForType:     software_finder.PathFinder,
+					InstallPath: "C:\\Games\\Battlefield\\mods\\some-mod\\objects.zip",
+					PathType:    software_finder.PathTypeFile,
+				},
+			},
+		},
+		{
+			name: "ignores finder configs not using path finder",
+			givenMod: GameMod{
+				Name: "some-mod",
+				Slug: "some-mod-slug",
+				finderConfigs: []software_finder.Config{
+					{
+						ForType:           software_finder.RegistryFinder,
+						RegistryKey:       software_finder.RegistryKeyLocalMachine,
+						RegistryPath:      "some-game\\some-mod",
+						RegistryValueName: "InstallPath",
+					},
+				},
+			},
+			givenGameInstallPath: "C:\\Games\\Battlefield",
+			expectedFinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "some-game\\some-mod",
+					RegistryValueName: "InstallPath",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			finderConfigs := tt.givenMod.ComputeFinderConfigs(tt.givenGameInstallPath)
+
+			// THEN
+			assert.Equal(t, tt.expectedFinderConfigs, finderConfigs)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+//go:build ignore
+
+package history
+
+//go:generate mockgen -source=history.go -destination=history_mock_test.go -package=$GOPACKAGE -write_package_comment=false
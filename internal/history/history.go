@@ -0,0 +1,150 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type Outcome string
+
+const (
+	// OutcomeLaunched Game was started (launcher did not wait for it to exit)
+	OutcomeLaunched Outcome = "launched"
+	// OutcomeExited Game was started and exited (without crashing, if crash detection is enabled)
+	OutcomeExited Outcome = "exited"
+	// OutcomeDryRun Game would have been started, but launcher was in dry run mode
+	OutcomeDryRun Outcome = "dry-run"
+	// OutcomeFailed Game could not be launched (or kept crashing)
+	OutcomeFailed Outcome = "failed"
+
+	fileName = "history.jsonl"
+	filePerm = 0644
+
+	// Lines can be long (e.g. many args/hooks), so allow up to 1MB per line
+	maxLineSize = 1024 * 1024
+)
+
+// Event A single launcher invocation (RunURL), persisted as one JSON line
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Scheme    string    `json:"scheme"`
+	// URL with any password redacted
+	URL         string    `json:"url"`
+	Action      string    `json:"action"`
+	InstallPath string    `json:"installPath,omitempty"`
+	Args        []string  `json:"args,omitempty"`
+	Hooks       []HookRun `json:"hooks,omitempty"`
+	Relaunches  int       `json:"relaunches,omitempty"`
+	ExitCode    *int      `json:"exitCode,omitempty"`
+	RuntimeMs   int64     `json:"runtimeMs,omitempty"`
+	Outcome     Outcome   `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type HookRun struct {
+	Handler    string `json:"handler"`
+	When       string `json:"when"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Filter Criteria to select events by (zero values match any event)
+type Filter struct {
+	Scheme  string
+	Outcome Outcome
+	// Maximum number of (most recent) events to return
+	Limit int
+}
+
+func (f Filter) matches(event Event) bool {
+	if f.Scheme != "" && event.Scheme != f.Scheme {
+		return false
+	}
+	if f.Outcome != "" && event.Outcome != f.Outcome {
+		return false
+	}
+	return true
+}
+
+type FileRepository interface {
+	ReadFile(path string) ([]byte, error)
+	OpenFile(path string, flag int, perm os.FileMode) (*os.File, error)
+}
+
+type History struct {
+	repository FileRepository
+	path       string
+}
+
+func New(repository FileRepository, path string) *History {
+	return &History{
+		repository: repository,
+		path:       path,
+	}
+}
+
+// BuildFilePath Returns the path of the history file, located next to the launcher executable (like the config file)
+func BuildFilePath() (string, error) {
+	wd, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(wd), fileName), nil
+}
+
+// Append Adds the event as a new line to the history file (creating the file if it does not exist yet)
+func (h *History) Append(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode history event: %s", err)
+	}
+
+	f, err := h.repository.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePerm)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %s", err)
+	}
+	defer f.Close()
+
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history event: %s", err)
+	}
+
+	return nil
+}
+
+// Read Returns all events matching the filter in chronological order. Lines which cannot be decoded (e.g. because
+// the launcher was terminated while writing them) or are longer than the maximum line size are skipped.
+func (h *History) Read(filter Filter) ([]Event, error) {
+	content, err := h.repository.ReadFile(h.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history file: %s", err)
+	}
+
+	events := make([]Event, 0)
+	for line := range bytes.Lines(content) {
+		if len(line) > maxLineSize {
+			continue
+		}
+		var event Event
+		if err = json.Unmarshal(line, &event); err != nil {
+			continue
+		}
+		if filter.matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+
+	return events, nil
+}
@@ -0,0 +1,188 @@
+//go:build unit
+
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHistory_Append(t *testing.T) {
+	t.Run("appends events as JSON lines", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		path := filepath.Join(t.TempDir(), fileName)
+		h := New(mockRepository, path)
+		exitCode := 1
+		givenEvents := []Event{
+			{
+				Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+				Scheme:    "bf2",
+				URL:       "bf2://127.0.0.1:16567?password=xxxxx",
+				Action:    "join",
+				Outcome:   OutcomeFailed,
+				Error:     "game not installed",
+			},
+			{
+				Timestamp:   time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+				Scheme:      "cod4",
+				URL:         "cod4://act/launch",
+				Action:      "launch",
+				InstallPath: "C:\\Games\\CoD4",
+				Args:        []string{"iw3mp.exe"},
+				Hooks: []HookRun{
+					{Handler: "kill-process", When: "pre-launch", DurationMs: 1500},
+				},
+				ExitCode:  &exitCode,
+				RuntimeMs: 60000,
+				Outcome:   OutcomeExited,
+			},
+		}
+
+		// EXPECT
+		mockRepository.EXPECT().OpenFile(gomock.Eq(path), gomock.Eq(os.O_CREATE|os.O_WRONLY|os.O_APPEND), gomock.Eq(os.FileMode(0644))).
+			DoAndReturn(os.OpenFile).
+			Times(len(givenEvents))
+
+		// WHEN
+		for _, event := range givenEvents {
+			require.NoError(t, h.Append(event))
+		}
+
+		// THEN
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `{"timestamp":"2024-01-01T12:00:00Z","scheme":"bf2","url":"bf2://127.0.0.1:16567?password=xxxxx","action":"join","outcome":"failed","error":"game not installed"}
+{"timestamp":"2024-01-01T13:00:00Z","scheme":"cod4","url":"cod4://act/launch","action":"launch","installPath":"C:\\Games\\CoD4","args":["iw3mp.exe"],"hooks":[{"handler":"kill-process","when":"pre-launch","durationMs":1500}],"exitCode":1,"runtimeMs":60000,"outcome":"exited"}
+`, string(content))
+	})
+
+	t.Run("errors if history file cannot be opened", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		h := New(mockRepository, fileName)
+
+		// EXPECT
+		mockRepository.EXPECT().OpenFile(gomock.Eq(fileName), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("some-error"))
+
+		// WHEN
+		err := h.Append(Event{Scheme: "bf2"})
+
+		// THEN
+		require.ErrorContains(t, err, "failed to open history file: some-error")
+	})
+}
+
+func TestHistory_Read(t *testing.T) {
+	givenContent := `{"timestamp":"2024-01-01T12:00:00Z","scheme":"bf2","url":"bf2://127.0.0.1:16567","action":"join","outcome":"failed","error":"game not installed"}
+{"timestamp":"2024-01-01T13:00:00Z","scheme":"cod4","url":"cod4://act/launch","action":"launch","outcome":"launched"}
+{"timestamp":"2024-01-01T14:00:00Z","scheme":"bf2","url":"bf2://act/lau
+{"timestamp":"2024-01-01T15:00:00Z","scheme":"bf2","url":"bf2://act/launch","action":"launch","outcome":"launched"}
+`
+
+	type test struct {
+		name            string
+		givenContent    *string
+		givenReadErr    error
+		givenFilter     Filter
+		expectedHours   []int
+		wantErrContains string
+	}
+
+	longLineContent := `{"timestamp":"2024-01-01T12:00:00Z","scheme":"bf2","url":"bf2://act/launch","action":"launch","outcome":"launched"}
+{"timestamp":"2024-01-01T13:00:00Z","scheme":"bf2","url":"bf2://act/launch","action":"launch","args":["` + strings.Repeat("a", maxLineSize) + `"],"outcome":"launched"}
+{"timestamp":"2024-01-01T14:00:00Z","scheme":"bf2","url":"bf2://act/launch","action":"launch","outcome":"launched"}
+`
+
+	tests := []test{
+		{
+			name:          "reads all events in chronological order, skipping invalid lines",
+			givenContent:  &givenContent,
+			givenFilter:   Filter{},
+			expectedHours: []int{12, 13, 15},
+		},
+		{
+			name:          "filters events by scheme",
+			givenContent:  &givenContent,
+			givenFilter:   Filter{Scheme: "bf2"},
+			expectedHours: []int{12, 15},
+		},
+		{
+			name:          "filters events by outcome",
+			givenContent:  &givenContent,
+			givenFilter:   Filter{Outcome: OutcomeLaunched},
+			expectedHours: []int{13, 15},
+		},
+		{
+			name:          "limits to most recent events",
+			givenContent:  &givenContent,
+			givenFilter:   Filter{Limit: 2},
+			expectedHours: []int{13, 15},
+		},
+		{
+			name:          "applies limit after filtering",
+			givenContent:  &givenContent,
+			givenFilter:   Filter{Scheme: "bf2", Limit: 1},
+			expectedHours: []int{15},
+		},
+		{
+			name:          "skips lines exceeding the maximum line size",
+			givenContent:  &longLineContent,
+			givenFilter:   Filter{},
+			expectedHours: []int{12, 14},
+		},
+		{
+			name:          "returns no events if history file does not exist",
+			givenReadErr:  os.ErrNotExist,
+			givenFilter:   Filter{},
+			expectedHours: nil,
+		},
+		{
+			name:            "errors if history file cannot be read",
+			givenReadErr:    os.ErrPermission,
+			givenFilter:     Filter{},
+			wantErrContains: "failed to read history file: permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			h := New(mockRepository, fileName)
+
+			// EXPECT
+			if tt.givenContent != nil {
+				mockRepository.EXPECT().ReadFile(gomock.Eq(fileName)).Return([]byte(*tt.givenContent), nil)
+			} else {
+				mockRepository.EXPECT().ReadFile(gomock.Eq(fileName)).Return(nil, tt.givenReadErr)
+			}
+
+			// WHEN
+			events, err := h.Read(tt.givenFilter)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				var hours []int
+				for _, event := range events {
+					hours = append(hours, event.Timestamp.Hour())
+				}
+				assert.Equal(t, tt.expectedHours, hours)
+			}
+		})
+	}
+}
@@ -3,3 +3,5 @@
 package router
 
 //go:generate mockgen -source=router.go -destination=router_mock_test.go -package=$GOPACKAGE -write_package_comment=false
+//go:generate mockgen -source=router_windows.go -destination=router_mock_windows_test.go -package=$GOPACKAGE -write_package_comment=false
+//go:generate mockgen -source=router_linux.go -destination=router_mock_linux_test.go -package=$GOPACKAGE -write_package_comment=false
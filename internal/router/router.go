@@ -1,47 +1,37 @@
 package router
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
-	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
-	"golang.org/x/sys/windows/registry"
+	"github.com/rs/zerolog/log"
 
 	"github.com/cetteup/joinme.click-launcher/internal"
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/internal/history"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+	"github.com/cetteup/joinme.click-launcher/pkg/version_detector"
 )
 
-type action int
-type actionPathKey string
-
 const (
-	regPathSoftware         = "SOFTWARE"
-	regPathClasses          = "Classes"
-	regPathOpen             = "open"
-	regPathShell            = "shell"
-	regPathCommand          = "command"
-	regValueNameDefault     = ""
-	regValueNameURLProtocol = "URL Protocol"
-
 	actionUrlHostname = "act"
+	// joinActionName Action recorded in the launch history for plain (non-action) URLs
+	joinActionName = "join"
 
-	actionLaunchAndJoin action = iota
-	actionLaunchOnly
-
-	actionPathKeyLaunch actionPathKey = "launch"
+	hostnameResolutionTimeout = 5 * time.Second
 )
 
-type RegistryRepository interface {
-	GetStringValue(k registry.Key, path string, valueName string) (string, error)
-	SetStringValue(k registry.Key, path string, valueName string, value string) error
-	CreateKey(k registry.Key, path string) error
-	DeleteKey(k registry.Key, path string) error
-}
+// ErrGameNotInstalled Game is not installed (according to any of its finder configs)
+var ErrGameNotInstalled = errors.New("game not installed")
 
 type GameFinder interface {
 	IsInstalledAnywhere(configs []software_finder.Config) (bool, error)
@@ -51,16 +41,49 @@ type GameFinder interface {
 }
 
 type GameLauncher interface {
-	StartGame(u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType, cmdBuilder game_launcher.CommandBuilder, hookHandlers ...game_launcher.HookHandler) error
+	StartGame(u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType, cmdBuilder game_launcher.CommandBuilder, hookHandlers ...game_launcher.HookHandler) (game_launcher.LaunchRecord, error)
+}
+
+type GameVersionDetector interface {
+	DetectVersion(path string) (string, error)
+}
+
+type ServerQuery interface {
+	Query(config server_query.Config, host string, gamePort int) (*server_query.ServerInfo, error)
+}
+
+type HostResolver interface {
+	LookupIP(ctx context.Context, network string, host string) ([]net.IP, error)
+}
+
+type LaunchHistory interface {
+	Append(event history.Event) error
 }
 
 type GameRouter struct {
-	repository RegistryRepository
+	repository handlerRepository
 	finder     GameFinder
 	launcher   GameLauncher
+	detector   GameVersionDetector
+	query      ServerQuery
+	resolver   HostResolver
+	history    LaunchHistory
 	GameTitles map[string]domain.GameTitle
 }
 
+func New(repository handlerRepository, finder GameFinder, launcher GameLauncher, detector GameVersionDetector, query ServerQuery, resolver HostResolver, history LaunchHistory) *GameRouter {
+	return &GameRouter{
+		repository: repository,
+		finder:     finder,
+		launcher:   launcher,
+		detector:   detector,
+		query:      query,
+		resolver:   resolver,
+		history:    history,
+		GameTitles: map[string]domain.GameTitle{},
+	}
+}
+
 type handlerRegistrationResult struct {
 	Title                   domain.GameTitle
 	GameInstalled           bool
@@ -70,13 +93,12 @@ type handlerRegistrationResult struct {
 	Error                   error
 }
 
-func New(repository RegistryRepository, finder GameFinder, launcher GameLauncher) *GameRouter {
-	return &GameRouter{
-		repository: repository,
-		finder:     finder,
-		launcher:   launcher,
-		GameTitles: map[string]domain.GameTitle{},
+// isRegistrable Whether the handler can (and needs to) be registered, based on the check results
+func (r handlerRegistrationResult) isRegistrable() bool {
+	if r.Error != nil || !r.GameInstalled || r.PreviouslyRegistered {
+		return false
 	}
+	return !r.Title.RequiresPlatformClient() || r.PlatformClientInstalled
 }
 
 func (r *GameRouter) AddTitle(gameTitles ...domain.GameTitle) {
@@ -85,6 +107,12 @@ func (r *GameRouter) AddTitle(gameTitles ...domain.GameTitle) {
 		if customConfig.HasValues() {
 			gt.AddCustomConfig(*customConfig)
 		}
+		if len(internal.Config.WinePrefixes) > 0 {
+			gt.AddWinePrefixes(internal.Config.WinePrefixes...)
+		}
+		if len(internal.Config.GOGLibraryPaths) > 0 {
+			gt.AddGOGLibraries(internal.Config.GOGLibraryPaths...)
+		}
 		r.GameTitles[gt.ProtocolScheme] = gt
 	}
 }
@@ -92,48 +120,10 @@ func (r *GameRouter) AddTitle(gameTitles ...domain.GameTitle) {
 func (r *GameRouter) RegisterHandlers() []handlerRegistrationResult {
 	results := make([]handlerRegistrationResult, 0, len(r.GameTitles))
 	for _, gameTitle := range r.GameTitles {
-		result := handlerRegistrationResult{
-			Title: gameTitle,
-		}
-
-		installed, err := r.finder.IsInstalledAnywhere(gameTitle.FinderConfigs)
-		if err != nil {
-			result.Error = fmt.Errorf("failed to determine whether game is installed: %e", err)
-			results = append(results, result)
-			continue
-		}
-		result.GameInstalled = installed
-
-		if !installed {
-			results = append(results, result)
-			continue
-		}
-
-		if gameTitle.RequiresPlatformClient() {
-			platformClientInstalled, err := r.finder.IsInstalled(gameTitle.PlatformClient.FinderConfig)
-			if err != nil {
-				result.Error = fmt.Errorf("failed to determine whether required platform (%s) is installed: %e", gameTitle.PlatformClient.Platform, err)
-				results = append(results, result)
-				continue
-			}
-			result.PlatformClientInstalled = platformClientInstalled
-
-			if !platformClientInstalled {
-				results = append(results, result)
-				continue
-			}
-		}
-
-		registered, err := r.isHandlerRegistered(gameTitle)
-		if err != nil {
-			result.Error = fmt.Errorf("failed to determine whether handler is registered: %e", err)
-			results = append(results, result)
-			continue
-		}
-		result.PreviouslyRegistered = registered
+		result := r.checkHandler(gameTitle)
 
-		if !registered {
-			if err = r.registerHandler(gameTitle); err != nil {
+		if result.isRegistrable() {
+			if err := r.registerHandler(gameTitle); err != nil {
 				result.Error = fmt.Errorf("failed to register as URL protocol handler: %e", err)
 			} else {
 				result.Registered = true
@@ -146,127 +136,142 @@ func (r *GameRouter) RegisterHandlers() []handlerRegistrationResult {
 	return results
 }
 
-func (r *GameRouter) DeregisterHandlers() error {
+// GetHandlerStatuses Checks whether each game (and any required platform client) is installed and whether
+// the launcher is registered as handler, without registering it
+func (r *GameRouter) GetHandlerStatuses() []handlerRegistrationResult {
+	results := make([]handlerRegistrationResult, 0, len(r.GameTitles))
 	for _, gameTitle := range r.GameTitles {
-		err := r.deregisterHandler(gameTitle)
-		if err != nil {
-			return fmt.Errorf("failed to deregister handler for %s (%s): %w", gameTitle.Name, gameTitle.ProtocolScheme, err)
-		}
+		results = append(results, r.checkHandler(gameTitle))
 	}
-	return nil
+
+	return results
 }
 
-func (r *GameRouter) isHandlerRegistered(gameTitle domain.GameTitle) (bool, error) {
-	path := r.getUrlHandlerRegistryPath(gameTitle, regPathShell, regPathOpen, regPathCommand)
-	value, err := r.repository.GetStringValue(registry.CURRENT_USER, path, regValueNameDefault)
+func (r *GameRouter) checkHandler(gameTitle domain.GameTitle) handlerRegistrationResult {
+	result := handlerRegistrationResult{
+		Title: gameTitle,
+	}
+
+	installed, err := r.finder.IsInstalledAnywhere(gameTitle.FinderConfigs)
 	if err != nil {
-		if errors.Is(err, registry.ErrNotExist) {
-			return false, nil
+		result.Error = fmt.Errorf("failed to determine whether game is installed: %e", err)
+		return result
+	}
+	result.GameInstalled = installed
+
+	if !installed {
+		return result
+	}
+
+	if gameTitle.RequiresPlatformClient() {
+		platformClientInstalled, err := r.finder.IsInstalled(gameTitle.PlatformClient.FinderConfig)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to determine whether required platform (%s) is installed: %e", gameTitle.PlatformClient.Platform, err)
+			return result
+		}
+		result.PlatformClientInstalled = platformClientInstalled
+
+		if !platformClientInstalled {
+			return result
 		}
-		return false, err
 	}
 
-	expected, err := r.getHandlerCommand()
+	registered, err := r.isHandlerRegistered(gameTitle)
 	if err != nil {
-		return false, err
+		result.Error = fmt.Errorf("failed to determine whether handler is registered: %e", err)
+		return result
 	}
+	result.PreviouslyRegistered = registered
 
-	return value == expected, nil
+	return result
 }
 
-func (r *GameRouter) registerHandler(gameTitle domain.GameTitle) error {
-	basePath := r.getUrlHandlerRegistryPath(gameTitle)
-	err := r.repository.CreateKey(registry.CURRENT_USER, basePath)
-	if err != nil {
+// CheckGame Checks whether the game can be launched via URLs, meaning it (and any required platform client) is installed,
+// its version is supported and the launcher is registered as handler
+func (r *GameRouter) CheckGame(gameTitle domain.GameTitle) error {
+	if err := r.ensureGameIsInstalled(gameTitle); err != nil {
 		return err
 	}
-
-	err = r.repository.SetStringValue(registry.CURRENT_USER, basePath, regValueNameDefault, fmt.Sprintf("URL:%s protocol", gameTitle.Name))
-	if err != nil {
+	if err := r.ensurePlatformClientIsInstalledIfRequired(gameTitle); err != nil {
 		return err
 	}
-	err = r.repository.SetStringValue(registry.CURRENT_USER, basePath, regValueNameURLProtocol, "")
-	if err != nil {
+	if err := r.ensureGameVersionIsSupported(gameTitle); err != nil {
 		return err
 	}
 
-	subKeys := []string{regPathShell, regPathOpen, regPathCommand}
-	for i := range subKeys {
-		subPath := r.getUrlHandlerRegistryPath(gameTitle, subKeys[:i+1]...)
-		err = r.repository.CreateKey(registry.CURRENT_USER, subPath)
-		if err != nil {
-			return err
-		}
-	}
-
-	cmdPath := r.getUrlHandlerRegistryPath(gameTitle, subKeys...)
-	cmd, err := r.getHandlerCommand()
+	registered, err := r.isHandlerRegistered(gameTitle)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to determine whether handler is registered: %s", err)
 	}
-
-	return r.repository.SetStringValue(registry.CURRENT_USER, cmdPath, regValueNameDefault, cmd)
-}
-
-func (r *GameRouter) deregisterHandler(gameTitle domain.GameTitle) error {
-	keys := []string{r.getUrlHandlerRegistryPath(gameTitle)}
-	subKeys := []string{regPathShell, regPathOpen, regPathCommand}
-	for i := range subKeys {
-		// We need to delete keys in reverse/"descending" order, so prepend to list
-		keys = append([]string{r.getUrlHandlerRegistryPath(gameTitle, subKeys[:i+1]...)}, keys...)
+	if !registered {
+		return fmt.Errorf("launcher is not registered as URL protocol handler")
 	}
 
-	for _, key := range keys {
-		err := r.repository.DeleteKey(registry.CURRENT_USER, key)
-		if err != nil && !errors.Is(err, registry.ErrNotExist) {
-			return err
-		}
-	}
 	return nil
 }
 
-func (r *GameRouter) getUrlHandlerRegistryPath(gameTitle domain.GameTitle, children ...string) string {
-	path := filepath.Join(regPathSoftware, regPathClasses, gameTitle.ProtocolScheme)
-	for _, child := range children {
-		path = filepath.Join(path, child)
+func (r *GameRouter) DeregisterHandlers() error {
+	for _, gameTitle := range r.GameTitles {
+		err := r.deregisterHandler(gameTitle)
+		if err != nil {
+			return fmt.Errorf("failed to deregister handler for %s (%s): %w", gameTitle.Name, gameTitle.ProtocolScheme, err)
+		}
 	}
-
-	return path
+	return nil
 }
 
-func (r *GameRouter) getHandlerCommand() (string, error) {
-	launcherPath, err := os.Executable()
-	if err != nil {
-		return "", err
+// RunURL Launches the game/runs the action for the given URL, recording the invocation in the launch history
+func (r *GameRouter) RunURL(commandLineUrl string) (gameTitle *domain.GameTitle, err error) {
+	event := history.Event{
+		Timestamp: time.Now(),
+		URL:       internal.RedactURL(commandLineUrl),
 	}
-	return fmt.Sprintf("\"%s\" \"%%1\"", launcherPath), nil
-}
+	defer func() {
+		r.recordEvent(event, err)
+	}()
 
-func (r *GameRouter) RunURL(commandLineUrl string) (*domain.GameTitle, error) {
 	u, err := url.Parse(commandLineUrl)
 	if err != nil {
+		// Parse errors contain the full URL, which may well contain a server password
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = internal.RedactURL(urlErr.URL)
+		}
 		return nil, err
 	}
 
-	gameTitle, ok := r.GameTitles[u.Scheme]
+	event.Scheme = u.Scheme
+	event.Action = joinActionName
+	if r.isActionURL(u) {
+		event.Action = strings.TrimPrefix(u.Path, "/")
+	}
+
+	title, ok := r.GameTitles[u.Scheme]
 	if !ok {
 		return nil, fmt.Errorf("game not supported: %s", u.Scheme)
 	}
 
-	if err = r.ensurePrerequisites(gameTitle, u); err != nil {
-		return &gameTitle, err
+	if err = r.ensurePrerequisites(title, u); err != nil {
+		return &title, err
 	}
 
-	action, err := r.getActionFromURL(u)
+	if r.isActionURL(u) {
+		return &title, r.runAction(title, u, &event)
+	}
+
+	return &title, r.joinServer(title, u, game_launcher.LaunchTypeLaunchAndJoin, &event)
+}
+
+// recordEvent Appends the event to the launch history, marking it as failed if an error is given (failing to append
+// is not considered an error, since the game was (not) launched regardless)
+func (r *GameRouter) recordEvent(event history.Event, err error) {
 	if err != nil {
-		return &gameTitle, err
+		event.Outcome = history.OutcomeFailed
+		event.Error = err.Error()
 	}
 
-	switch action {
-	case actionLaunchOnly:
-		return &gameTitle, r.startGame(gameTitle, u, game_launcher.LaunchTypeLaunchOnly)
-	default:
-		return &gameTitle, r.startGame(gameTitle, u, game_launcher.LaunchTypeLaunchAndJoin)
+	if appendErr := r.history.Append(event); appendErr != nil {
+		log.Warn().Err(appendErr).Msg("Failed to record launch in history")
 	}
 }
 
@@ -277,6 +282,9 @@ func (r *GameRouter) ensurePrerequisites(gameTitle domain.GameTitle, u *url.URL)
 	if err := r.ensurePlatformClientIsInstalledIfRequired(gameTitle); err != nil {
 		return err
 	}
+	if err := r.ensureGameVersionIsSupported(gameTitle); err != nil {
+		return err
+	}
 	if err := r.ensureModIsSupportedAndInstalledIfGiven(gameTitle, u); err != nil {
 		return err
 	}
@@ -290,7 +298,7 @@ func (r *GameRouter) ensureGameIsInstalled(gameTitle domain.GameTitle) error {
 		return err
 	}
 	if !gameInstalled {
-		return fmt.Errorf("game not installed")
+		return ErrGameNotInstalled
 	}
 
 	return nil
@@ -312,6 +320,41 @@ func (r *GameRouter) ensurePlatformClientIsInstalledIfRequired(gameTitle domain.
 	return nil
 }
 
+func (r *GameRouter) ensureGameVersionIsSupported(gameTitle domain.GameTitle) error {
+	if gameTitle.MinimumVersion == "" {
+		return nil
+	}
+
+	version, err := r.GetGameVersion(gameTitle)
+	if err != nil {
+		return fmt.Errorf("failed to determine game version: %s", err)
+	}
+
+	comparison, err := version_detector.CompareVersions(version, gameTitle.MinimumVersion)
+	if err != nil {
+		return err
+	}
+	if comparison < 0 {
+		return fmt.Errorf("game needs patch %s (installed version: %s)", gameTitle.MinimumVersion, version)
+	}
+
+	return nil
+}
+
+// GetGameVersion Detects the version of the game's executable
+func (r *GameRouter) GetGameVersion(gameTitle domain.GameTitle) (string, error) {
+	installPath, err := r.finder.GetInstallDirFromSomewhere(gameTitle.FinderConfigs)
+	if err != nil {
+		return "", err
+	}
+
+	// Executable paths are defined with Windows separators, so convert them to whatever the host uses
+	executablePath := filepath.FromSlash(strings.ReplaceAll(gameTitle.LauncherConfig.ExecutablePath, "\\", "/"))
+	path := filepath.Join(installPath, executablePath, gameTitle.LauncherConfig.ExecutableName)
+
+	return r.detector.DetectVersion(path)
+}
+
 func (r *GameRouter) ensureModIsSupportedAndInstalledIfGiven(gameTitle domain.GameTitle, u *url.URL) error {
 	query := u.Query()
 	if !internal.QueryHasMod(query) {
@@ -339,22 +382,184 @@ func (r *GameRouter) ensureModIsSupportedAndInstalledIfGiven(gameTitle domain.Ga
 	return nil
 }
 
-func (r *GameRouter) startGame(gameTitle domain.GameTitle, u *url.URL, launchType game_launcher.LaunchType) error {
-	// Only join url use/require URL parameters, so only validate those
-	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
-		err := gameTitle.URLValidator.Validate(u)
-		if err != nil {
+func (r *GameRouter) runAction(gameTitle domain.GameTitle, u *url.URL, event *history.Event) error {
+	name := strings.TrimPrefix(u.Path, "/")
+	action := gameTitle.GetAction(name)
+	if action == nil {
+		return fmt.Errorf("action not supported by %s: %s", gameTitle.Name, name)
+	}
+
+	if action.URLValidator != nil {
+		if err := action.URLValidator.Validate(u); err != nil {
 			return err
 		}
 	}
 
+	if action.ServerURLBuilder != nil {
+		return r.joinServer(gameTitle, action.ServerURLBuilder(u), action.LaunchType, event)
+	}
+
+	return r.startGame(gameTitle, u, action.LaunchType, nil, event)
+}
+
+func (r *GameRouter) joinServer(gameTitle domain.GameTitle, u *url.URL, launchType game_launcher.LaunchType, event *history.Event) error {
+	err := gameTitle.URLValidator.Validate(u)
+	if err != nil {
+		return err
+	}
+
+	// Most games cannot resolve hostnames themselves, so pass them the resolved address instead
+	u, err = r.resolveHostnameIfGiven(u, gameTitle.URLValidator)
+	if err != nil {
+		return err
+	}
+
+	// Query server before launching, since launching usually involves killing any running game instance
+	info, err := r.ensureServerIsJoinableIfQueryable(gameTitle, u)
+	if err != nil {
+		return err
+	}
+
+	return r.startGame(gameTitle, u, launchType, info, event)
+}
+
+// startGame Launches the game, passing any additional arguments required for the server (if any info is given),
+// and adds the details of the launch to the event
+func (r *GameRouter) startGame(gameTitle domain.GameTitle, u *url.URL, launchType game_launcher.LaunchType, info *server_query.ServerInfo, event *history.Event) error {
 	// Build final launcher config
 	launcherConfig, err := r.buildLauncherConfig(gameTitle)
 	if err != nil {
 		return err
 	}
+	event.InstallPath = launcherConfig.InstallPath
 
-	return r.launcher.StartGame(u, launcherConfig, launchType, gameTitle.CmdBuilder, gameTitle.HookHandlers...)
+	if info != nil && gameTitle.ServerArgsBuilder != nil {
+		if serverArgs := gameTitle.ServerArgsBuilder.GetServerArgs(info); len(serverArgs) > 0 {
+			// Copy default args, since the underlying array is shared with the (global) title definition
+			launcherConfig.DefaultArgs = append(append([]string{}, launcherConfig.DefaultArgs...), serverArgs...)
+		}
+	}
+
+	if gameTitle.LaunchReport {
+		reportDir, err2 := internal.BuildLaunchReportDirPath(gameTitle.ProtocolScheme, time.Now())
+		if err2 != nil {
+			return fmt.Errorf("failed to determine launch report dir: %s", err2)
+		}
+		launcherConfig.ReportDir = reportDir
+		launcherConfig.LogFiles = gameTitle.LogFiles
+	}
+
+	record, err := r.launcher.StartGame(u, launcherConfig, launchType, gameTitle.CmdBuilder, gameTitle.HookHandlers...)
+	addLaunchRecordToEvent(event, record)
+	return err
+}
+
+func addLaunchRecordToEvent(event *history.Event, record game_launcher.LaunchRecord) {
+	event.Args = record.Args
+	event.Relaunches = record.Relaunches
+	for _, hook := range record.Hooks {
+		run := history.HookRun{
+			Handler:    hook.Handler,
+			When:       string(hook.When),
+			DurationMs: hook.Duration.Milliseconds(),
+		}
+		if hook.Err != nil {
+			run.Error = hook.Err.Error()
+		}
+		event.Hooks = append(event.Hooks, run)
+	}
+
+	switch {
+	case record.DryRun:
+		event.Outcome = history.OutcomeDryRun
+	case record.Exit != nil:
+		exitCode := record.Exit.ExitCode
+		event.ExitCode = &exitCode
+		event.RuntimeMs = record.Exit.Runtime.Milliseconds()
+		event.Outcome = history.OutcomeExited
+	default:
+		event.Outcome = history.OutcomeLaunched
+	}
+}
+
+// resolveHostnameIfGiven Returns a copy of the URL with the hostname replaced by its (first) IP address, or the URL
+// itself if it does not contain a hostname and port (e.g. IP addresses or game ids). IPv6 addresses are preferred
+// if the game supports IPv6 servers (according to its URL validator), else IPv4 addresses are used.
+func (r *GameRouter) resolveHostnameIfGiven(u *url.URL, validator game_launcher.URLValidator) (*url.URL, error) {
+	hostname := u.Hostname()
+	if u.Port() == "" || !internal.IsValidHostname(hostname) {
+		return u, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostnameResolutionTimeout)
+	defer cancel()
+
+	ips, err := r.resolver.LookupIP(ctx, "ip", hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve server hostname %s: %s", hostname, err)
+	}
+
+	var ipv4, ipv6 *url.URL
+	for _, ip := range ips {
+		resolved := *u
+		resolved.Host = net.JoinHostPort(ip.String(), u.Port())
+		if ip.To4() != nil && ipv4 == nil {
+			ipv4 = &resolved
+		} else if ip.To4() == nil && ipv6 == nil {
+			ipv6 = &resolved
+		}
+	}
+
+	resolved := ipv4
+	if ipv6 != nil && validator.Validate(ipv6) == nil {
+		resolved = ipv6
+	}
+	if resolved == nil {
+		return nil, fmt.Errorf("server hostname did not resolve to any supported IP address: %s", hostname)
+	}
+
+	log.Info().Str("hostname", hostname).Str("ip", resolved.Hostname()).Msg("Resolved server hostname")
+
+	return resolved, nil
+}
+
+// ensureServerIsJoinableIfQueryable Queries the server if the game supports it, returning the query results
+// (nil if the game does not support queries or the server could not be queried)
+func (r *GameRouter) ensureServerIsJoinableIfQueryable(gameTitle domain.GameTitle, u *url.URL) (*server_query.ServerInfo, error) {
+	if gameTitle.QueryConfig == nil {
+		return nil, nil
+	}
+
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		return nil, fmt.Errorf("invalid server port: %s", u.Port())
+	}
+
+	info, err := r.query.Query(*gameTitle.QueryConfig, u.Hostname(), port)
+	if err != nil {
+		if gameTitle.AbortIfServerUnreachable {
+			return nil, fmt.Errorf("server is unreachable: %s", err)
+		}
+		log.Warn().Err(err).Str("server", u.Host).Msg("Failed to query server, launching game anyway")
+		return nil, nil
+	}
+
+	log.Info().
+		Str("server", u.Host).
+		Str("name", info.Name).
+		Str("map", info.Map).
+		Str("gameType", info.GameType).
+		Str("mod", info.Mod).
+		Int("players", info.NumPlayers).
+		Int("maxPlayers", info.MaxPlayers).
+		Bool("password", info.Password).
+		Msg("Server is online")
+
+	if info.IsFull() && gameTitle.AbortIfServerFull {
+		return nil, fmt.Errorf("server is full: %d/%d players", info.NumPlayers, info.MaxPlayers)
+	}
+
+	return info, nil
 }
 
 func (r *GameRouter) buildLauncherConfig(gameTitle domain.GameTitle) (game_launcher.Config, error) {
@@ -378,19 +583,6 @@ func (r *GameRouter) buildLauncherConfig(gameTitle domain.GameTitle) (game_launc
 	return launcherConfig, nil
 }
 
-func (r *GameRouter) getActionFromURL(u *url.URL) (action, error) {
-	if !r.isActionURL(u) {
-		return actionLaunchAndJoin, nil
-	}
-
-	switch strings.TrimPrefix(u.Path, "/") {
-	case string(actionPathKeyLaunch):
-		return actionLaunchOnly, nil
-	default:
-		return 0, fmt.Errorf("action not supported: %s", u.Path)
-	}
-}
-
 func (r *GameRouter) isActionURL(u *url.URL) bool {
 	return u.Hostname() == actionUrlHostname
 }
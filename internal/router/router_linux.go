@@ -0,0 +1,317 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+)
+
+const (
+	xdgDataHomeEnvKey        = "XDG_DATA_HOME"
+	xdgConfigHomeEnvKey      = "XDG_CONFIG_HOME"
+	xdgDataHomeDefault       = ".local/share"
+	xdgConfigHomeDefault     = ".config"
+	xdgApplicationsDirName   = "applications"
+	mimeAppsListFileName     = "mimeapps.list"
+	mimeAppsListDefaultGroup = "[Default Applications]"
+
+	desktopFileNameTemplate = "joinme.click-launcher-%s.desktop"
+	desktopFilePerm         = 0644
+	desktopDirPerm          = 0755
+	mimeTypeSchemeTemplate  = "x-scheme-handler/%s"
+)
+
+type FileRepository interface {
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	RemoveAll(path string) error
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+// URL protocol handlers are registered via XDG desktop entries (plus the user's mimeapps.list)
+type handlerRepository = FileRepository
+
+func (r *GameRouter) isHandlerRegistered(gameTitle domain.GameTitle) (bool, error) {
+	desktopFilePath, err := r.getDesktopFilePath(gameTitle)
+	if err != nil {
+		return false, err
+	}
+	content, err := r.repository.ReadFile(desktopFilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	expected, err := r.getDesktopEntry(gameTitle)
+	if err != nil {
+		return false, err
+	}
+	if string(content) != expected {
+		return false, nil
+	}
+
+	mimeAppsList, err := r.readMimeAppsList()
+	if err != nil {
+		return false, err
+	}
+
+	return getMimeAppsListDefault(mimeAppsList, getSchemeMimeType(gameTitle)) == getDesktopFileName(gameTitle), nil
+}
+
+func (r *GameRouter) registerHandler(gameTitle domain.GameTitle) error {
+	desktopFilePath, err := r.getDesktopFilePath(gameTitle)
+	if err != nil {
+		return err
+	}
+	entry, err := r.getDesktopEntry(gameTitle)
+	if err != nil {
+		return err
+	}
+	if err = r.writeFile(desktopFilePath, []byte(entry)); err != nil {
+		return err
+	}
+
+	mimeAppsList, err := r.readMimeAppsList()
+	if err != nil {
+		return err
+	}
+	mimeAppsList = setMimeAppsListDefault(mimeAppsList, getSchemeMimeType(gameTitle), getDesktopFileName(gameTitle))
+
+	return r.writeMimeAppsList(mimeAppsList)
+}
+
+func (r *GameRouter) deregisterHandler(gameTitle domain.GameTitle) error {
+	mimeAppsList, err := r.readMimeAppsList()
+	if err != nil {
+		return err
+	}
+	// Only touch the mimeapps.list if we are the default handler, leaving any other handlers untouched
+	if getMimeAppsListDefault(mimeAppsList, getSchemeMimeType(gameTitle)) == getDesktopFileName(gameTitle) {
+		mimeAppsList = removeMimeAppsListDefault(mimeAppsList, getSchemeMimeType(gameTitle))
+		if err = r.writeMimeAppsList(mimeAppsList); err != nil {
+			return err
+		}
+	}
+
+	desktopFilePath, err := r.getDesktopFilePath(gameTitle)
+	if err != nil {
+		return err
+	}
+
+	return r.repository.RemoveAll(desktopFilePath)
+}
+
+func (r *GameRouter) readMimeAppsList() (string, error) {
+	path, err := getMimeAppsListPath()
+	if err != nil {
+		return "", err
+	}
+	content, err := r.repository.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(content), nil
+}
+
+func (r *GameRouter) writeMimeAppsList(content string) error {
+	path, err := getMimeAppsListPath()
+	if err != nil {
+		return err
+	}
+	return r.writeFile(path, []byte(content))
+}
+
+// writeFile Writes the file, creating any missing parent dirs first (which may not exist yet on a fresh profile)
+func (r *GameRouter) writeFile(path string, data []byte) error {
+	if err := r.repository.MkdirAll(filepath.Dir(path), desktopDirPerm); err != nil {
+		return err
+	}
+	return r.repository.WriteFile(path, data, desktopFilePerm)
+}
+
+func (r *GameRouter) getDesktopFilePath(gameTitle domain.GameTitle) (string, error) {
+	dataHome, err := getXDGBaseDir(xdgDataHomeEnvKey, xdgDataHomeDefault)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataHome, xdgApplicationsDirName, getDesktopFileName(gameTitle)), nil
+}
+
+func (r *GameRouter) getDesktopEntry(gameTitle domain.GameTitle) (string, error) {
+	cmd, err := r.getHandlerCommand()
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		"[Desktop Entry]",
+		"Type=Application",
+		fmt.Sprintf("Name=joinme.click launcher (%s)", gameTitle.Name),
+		fmt.Sprintf("Exec=%s", cmd),
+		// Keep a terminal open, so the launcher output is visible (as it is on Windows)
+		"Terminal=true",
+		"NoDisplay=true",
+		fmt.Sprintf("MimeType=%s;", getSchemeMimeType(gameTitle)),
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func (r *GameRouter) getHandlerCommand() (string, error) {
+	launcherPath, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %%u", quoteDesktopExecArg(launcherPath)), nil
+}
+
+func getDesktopFileName(gameTitle domain.GameTitle) string {
+	return fmt.Sprintf(desktopFileNameTemplate, gameTitle.ProtocolScheme)
+}
+
+func getSchemeMimeType(gameTitle domain.GameTitle) string {
+	return fmt.Sprintf(mimeTypeSchemeTemplate, gameTitle.ProtocolScheme)
+}
+
+func getMimeAppsListPath() (string, error) {
+	configHome, err := getXDGBaseDir(xdgConfigHomeEnvKey, xdgConfigHomeDefault)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configHome, mimeAppsListFileName), nil
+}
+
+// getXDGBaseDir Returns the base directory set via the given environment variable, falling back to the given
+// default relative to the user's home directory (see https://specifications.freedesktop.org/basedir-spec/latest/)
+func getXDGBaseDir(envKey string, fallback string) (string, error) {
+	if dir := os.Getenv(envKey); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, fallback), nil
+}
+
+// quoteDesktopExecArg Quotes an argument according to the desktop entry spec (double quotes, with ", `, $ and \
+// escaped by a backslash), followed by the escaping required for any desktop entry string value (\ becoming \\)
+func quoteDesktopExecArg(arg string) string {
+	replacer := strings.NewReplacer(`"`, `\"`, "`", "\\`", `$`, `\$`, `\`, `\\`)
+	quoted := fmt.Sprintf(`"%s"`, replacer.Replace(arg))
+	return strings.ReplaceAll(quoted, `\`, `\\`)
+}
+
+func getMimeAppsListDefault(content string, mimeType string) string {
+	for _, line := range getMimeAppsListDefaultGroupLines(content) {
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == mimeType {
+			// Value is a list of desktop files in order of preference
+			first, _, _ := strings.Cut(strings.TrimSpace(value), ";")
+			return first
+		}
+	}
+	return ""
+}
+
+func setMimeAppsListDefault(content string, mimeType string, desktopFileName string) string {
+	entry := fmt.Sprintf("%s=%s;", mimeType, desktopFileName)
+	lines := splitMimeAppsList(content)
+
+	start, end := findMimeAppsListDefaultGroup(lines)
+	if start == -1 {
+		lines = append(lines, mimeAppsListDefaultGroup, entry)
+		return joinMimeAppsList(lines)
+	}
+
+	for i := start + 1; i < end; i++ {
+		if isMimeAppsListEntryFor(lines[i], mimeType) {
+			lines[i] = entry
+			return joinMimeAppsList(lines)
+		}
+	}
+
+	// Add entry after the last non-empty line of the group
+	insertAt := end
+	for insertAt > start+1 && strings.TrimSpace(lines[insertAt-1]) == "" {
+		insertAt--
+	}
+	lines = append(lines[:insertAt], append([]string{entry}, lines[insertAt:]...)...)
+	return joinMimeAppsList(lines)
+}
+
+func removeMimeAppsListDefault(content string, mimeType string) string {
+	lines := splitMimeAppsList(content)
+
+	start, end := findMimeAppsListDefaultGroup(lines)
+	if start == -1 {
+		return content
+	}
+
+	filtered := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if i > start && i < end && isMimeAppsListEntryFor(line, mimeType) {
+			continue
+		}
+		filtered = append(filtered, line)
+	}
+	return joinMimeAppsList(filtered)
+}
+
+func getMimeAppsListDefaultGroupLines(content string) []string {
+	lines := splitMimeAppsList(content)
+	start, end := findMimeAppsListDefaultGroup(lines)
+	if start == -1 {
+		return nil
+	}
+	return lines[start+1 : end]
+}
+
+// findMimeAppsListDefaultGroup Returns the index of the default group header and the index of the first line after
+// the group (-1, -1 if the group does not exist)
+func findMimeAppsListDefaultGroup(lines []string) (int, int) {
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start == -1 {
+			if trimmed == mimeAppsListDefaultGroup {
+				start = i
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			return start, i
+		}
+	}
+	if start == -1 {
+		return -1, -1
+	}
+	return start, len(lines)
+}
+
+func isMimeAppsListEntryFor(line string, mimeType string) bool {
+	key, _, found := strings.Cut(line, "=")
+	return found && strings.TrimSpace(key) == mimeType
+}
+
+func splitMimeAppsList(content string) []string {
+	trimmed := strings.TrimRight(content, "\n")
+	if trimmed == "" {
+		return []string{}
+	}
+	return strings.Split(trimmed, "\n")
+}
+
+func joinMimeAppsList(lines []string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,461 @@
+//go:build unit
+
+package router
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+)
+
+func TestGameRouter_RegisterHandlers(t *testing.T) {
+	t.Setenv(xdgDataHomeEnvKey, "/home/user/.local/share")
+	t.Setenv(xdgConfigHomeEnvKey, "/home/user/.config")
+
+	title := domain.GameTitle{
+		Name:           "some-name",
+		ProtocolScheme: "some-protocol",
+		FinderConfigs: []software_finder.Config{
+			{
+				ForType:     software_finder.PathFinder,
+				InstallPath: "/games/some-game",
+				PathType:    software_finder.PathTypeDir,
+			},
+		},
+	}
+	applicationsDir := "/home/user/.local/share/applications"
+	configHome := "/home/user/.config"
+	desktopFilePath := "/home/user/.local/share/applications/joinme.click-launcher-some-protocol.desktop"
+	mimeAppsListPath := "/home/user/.config/mimeapps.list"
+
+	t.Run("successfully registers handlers", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		desktopEntry, err := router.getDesktopEntry(title)
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, os.ErrNotExist)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(applicationsDir), gomock.Eq(os.FileMode(0755)))
+		mockRepository.EXPECT().WriteFile(gomock.Eq(desktopFilePath), gomock.Eq([]byte(desktopEntry)), gomock.Any())
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return([]byte("[Default Applications]\ntext/html=firefox.desktop;\n"), nil)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(configHome), gomock.Eq(os.FileMode(0755)))
+		mockRepository.EXPECT().WriteFile(gomock.Eq(mimeAppsListPath), gomock.Eq([]byte("[Default Applications]\ntext/html=firefox.desktop;\nx-scheme-handler/some-protocol=joinme.click-launcher-some-protocol.desktop;\n")), gomock.Any())
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    false,
+			Registered:              true,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("successfully registers handler if desktop entry exists but is not the default", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		desktopEntry, err := router.getDesktopEntry(title)
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return([]byte(desktopEntry), nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return(nil, os.ErrNotExist).Times(2)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(applicationsDir), gomock.Eq(os.FileMode(0755)))
+		mockRepository.EXPECT().WriteFile(gomock.Eq(desktopFilePath), gomock.Eq([]byte(desktopEntry)), gomock.Any())
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(configHome), gomock.Eq(os.FileMode(0755)))
+		mockRepository.EXPECT().WriteFile(gomock.Eq(mimeAppsListPath), gomock.Eq([]byte("[Default Applications]\nx-scheme-handler/some-protocol=joinme.click-launcher-some-protocol.desktop;\n")), gomock.Any())
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.True(t, result[0].Registered)
+		assert.False(t, result[0].PreviouslyRegistered)
+		assert.NoError(t, result[0].Error)
+	})
+
+	t.Run("skips game if handler is already registered", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		desktopEntry, err := router.getDesktopEntry(title)
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return([]byte(desktopEntry), nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return([]byte("[Default Applications]\nx-scheme-handler/some-protocol=joinme.click-launcher-some-protocol.desktop;\n"), nil)
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    true,
+			Registered:              false,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("error if handler registration check fails", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, title, result[0].Title)
+		require.ErrorContains(t, result[0].Error, "failed to determine whether handler is registered")
+	})
+
+	t.Run("creates missing dirs before registering handlers on fresh profile", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, os.ErrNotExist)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return(nil, os.ErrNotExist)
+		gomock.InOrder(
+			mockRepository.EXPECT().MkdirAll(gomock.Eq(applicationsDir), gomock.Eq(os.FileMode(0755))),
+			mockRepository.EXPECT().WriteFile(gomock.Eq(desktopFilePath), gomock.Any(), gomock.Any()),
+			mockRepository.EXPECT().MkdirAll(gomock.Eq(configHome), gomock.Eq(os.FileMode(0755))),
+			mockRepository.EXPECT().WriteFile(gomock.Eq(mimeAppsListPath), gomock.Any(), gomock.Any()),
+		)
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.True(t, result[0].Registered)
+		assert.NoError(t, result[0].Error)
+	})
+
+	t.Run("error if applications dir cannot be created", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, os.ErrNotExist)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(applicationsDir), gomock.Eq(os.FileMode(0755))).Return(fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		require.ErrorContains(t, result[0].Error, "failed to register as URL protocol handler")
+	})
+
+	t.Run("error if handler registration fails", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, os.ErrNotExist)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(applicationsDir), gomock.Eq(os.FileMode(0755)))
+		mockRepository.EXPECT().WriteFile(gomock.Eq(desktopFilePath), gomock.Any(), gomock.Any()).Return(fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, title, result[0].Title)
+		require.ErrorContains(t, result[0].Error, "failed to register as URL protocol handler")
+	})
+}
+
+func TestGameRouter_GetHandlerStatuses(t *testing.T) {
+	t.Setenv(xdgDataHomeEnvKey, "/home/user/.local/share")
+	t.Setenv(xdgConfigHomeEnvKey, "/home/user/.config")
+
+	title := domain.GameTitle{
+		Name:           "some-name",
+		ProtocolScheme: "some-protocol",
+		FinderConfigs: []software_finder.Config{
+			{
+				ForType:     software_finder.PathFinder,
+				InstallPath: "/games/some-game",
+				PathType:    software_finder.PathTypeDir,
+			},
+		},
+	}
+	desktopFilePath := "/home/user/.local/share/applications/joinme.click-launcher-some-protocol.desktop"
+
+	t.Run("checks status without registering handlers", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		// EXPECT
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, os.ErrNotExist)
+
+		// WHEN
+		result := router.GetHandlerStatuses()
+
+		// THEN
+		assert.Equal(t, []handlerRegistrationResult{
+			{
+				Title:                title,
+				GameInstalled:        true,
+				PreviouslyRegistered: false,
+				Registered:           false,
+			},
+		}, result)
+	})
+}
+
+func TestGameRouter_CheckGame(t *testing.T) {
+	t.Setenv(xdgDataHomeEnvKey, "/home/user/.local/share")
+	t.Setenv(xdgConfigHomeEnvKey, "/home/user/.config")
+
+	title := domain.GameTitle{
+		Name:           "some-name",
+		ProtocolScheme: "some-protocol",
+		FinderConfigs: []software_finder.Config{
+			{
+				ForType:     software_finder.PathFinder,
+				InstallPath: "/games/some-game",
+				PathType:    software_finder.PathTypeDir,
+			},
+		},
+	}
+	desktopFilePath := "/home/user/.local/share/applications/joinme.click-launcher-some-protocol.desktop"
+	mimeAppsListPath := "/home/user/.config/mimeapps.list"
+
+	type test struct {
+		name            string
+		expect          func(router *GameRouter, repository *MockFileRepository, finder *MockGameFinder)
+		wantErr         error
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name: "successfully checks game",
+			expect: func(router *GameRouter, repository *MockFileRepository, finder *MockGameFinder) {
+				desktopEntry, err := router.getDesktopEntry(title)
+				require.NoError(t, err)
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				repository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return([]byte(desktopEntry), nil)
+				repository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return([]byte("[Default Applications]\nx-scheme-handler/some-protocol=joinme.click-launcher-some-protocol.desktop;\n"), nil)
+			},
+		},
+		{
+			name: "error if game is not installed",
+			expect: func(router *GameRouter, repository *MockFileRepository, finder *MockGameFinder) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(false, nil)
+			},
+			wantErr: ErrGameNotInstalled,
+		},
+		{
+			name: "error if handler is not registered",
+			expect: func(router *GameRouter, repository *MockFileRepository, finder *MockGameFinder) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				repository.EXPECT().ReadFile(gomock.Eq(desktopFilePath)).Return(nil, os.ErrNotExist)
+			},
+			wantErrContains: "launcher is not registered as URL protocol handler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+			// EXPECT
+			tt.expect(router, mockRepository, mockFinder)
+
+			// WHEN
+			err := router.CheckGame(title)
+
+			// THEN
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrContains != "":
+				require.ErrorContains(t, err, tt.wantErrContains)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGameRouter_DeregisterHandlers(t *testing.T) {
+	t.Setenv(xdgDataHomeEnvKey, "/home/user/.local/share")
+	t.Setenv(xdgConfigHomeEnvKey, "/home/user/.config")
+
+	title := domain.GameTitle{
+		Name:           "some-name",
+		ProtocolScheme: "some-protocol",
+	}
+	configHome := "/home/user/.config"
+	desktopFilePath := "/home/user/.local/share/applications/joinme.click-launcher-some-protocol.desktop"
+	mimeAppsListPath := "/home/user/.config/mimeapps.list"
+
+	t.Run("successfully deregisters handlers", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, _, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return([]byte("[Default Applications]\nx-scheme-handler/some-protocol=joinme.click-launcher-some-protocol.desktop;\ntext/html=firefox.desktop;\n"), nil)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(configHome), gomock.Eq(os.FileMode(0755)))
+		mockRepository.EXPECT().WriteFile(gomock.Eq(mimeAppsListPath), gomock.Eq([]byte("[Default Applications]\ntext/html=firefox.desktop;\n")), gomock.Any())
+		mockRepository.EXPECT().RemoveAll(gomock.Eq(desktopFilePath))
+
+		// WHEN
+		err := router.DeregisterHandlers()
+
+		// THEN
+		assert.NoError(t, err)
+	})
+
+	t.Run("does not touch handlers registered by other applications", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, _, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return([]byte("[Default Applications]\nx-scheme-handler/some-protocol=other.desktop;\n"), nil)
+		mockRepository.EXPECT().RemoveAll(gomock.Eq(desktopFilePath))
+
+		// WHEN
+		err := router.DeregisterHandlers()
+
+		// THEN
+		assert.NoError(t, err)
+	})
+
+	t.Run("error if desktop entry deletion fails", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, _, _, _, _, _, _ := getRouterWithDependencies(t)
+		router.AddTitle(title)
+
+		mockRepository.EXPECT().ReadFile(gomock.Eq(mimeAppsListPath)).Return(nil, os.ErrNotExist)
+		mockRepository.EXPECT().RemoveAll(gomock.Eq(desktopFilePath)).Return(fmt.Errorf("some-error"))
+
+		// WHEN
+		err := router.DeregisterHandlers()
+
+		// THEN
+		assert.ErrorContains(t, err, "failed to deregister handler for some-name (some-protocol): some-error")
+	})
+}
+
+func TestSetMimeAppsListDefault(t *testing.T) {
+	type test struct {
+		name            string
+		givenContent    string
+		expectedContent string
+	}
+
+	tests := []test{
+		{
+			name:            "adds group and entry to empty list",
+			givenContent:    "",
+			expectedContent: "[Default Applications]\nx-scheme-handler/bf2=launcher.desktop;\n",
+		},
+		{
+			name:            "adds entry to end of existing group",
+			givenContent:    "[Default Applications]\ntext/html=firefox.desktop;\n\n[Added Associations]\ntext/plain=gedit.desktop;\n",
+			expectedContent: "[Default Applications]\ntext/html=firefox.desktop;\nx-scheme-handler/bf2=launcher.desktop;\n\n[Added Associations]\ntext/plain=gedit.desktop;\n",
+		},
+		{
+			name:            "replaces existing entry",
+			givenContent:    "[Default Applications]\nx-scheme-handler/bf2=other.desktop;\n",
+			expectedContent: "[Default Applications]\nx-scheme-handler/bf2=launcher.desktop;\n",
+		},
+		{
+			name:            "adds group if only other groups exist",
+			givenContent:    "[Added Associations]\nx-scheme-handler/bf2=other.desktop;\n",
+			expectedContent: "[Added Associations]\nx-scheme-handler/bf2=other.desktop;\n[Default Applications]\nx-scheme-handler/bf2=launcher.desktop;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			content := setMimeAppsListDefault(tt.givenContent, "x-scheme-handler/bf2", "launcher.desktop")
+
+			// THEN
+			assert.Equal(t, tt.expectedContent, content)
+			assert.Equal(t, "launcher.desktop", getMimeAppsListDefault(content, "x-scheme-handler/bf2"))
+		})
+	}
+}
+
+func TestQuoteDesktopExecArg(t *testing.T) {
+	type test struct {
+		name           string
+		givenArg       string
+		expectedQuoted string
+	}
+
+	tests := []test{
+		{
+			name:           "quotes plain path",
+			givenArg:       "/opt/joinme.click-launcher/joinme.click-launcher",
+			expectedQuoted: `"/opt/joinme.click-launcher/joinme.click-launcher"`,
+		},
+		{
+			name:           "escapes reserved characters",
+			givenArg:       "/home/some \"user\"/$bin/launcher",
+			expectedQuoted: `"/home/some \\"user\\"/\\$bin/launcher"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			quoted := quoteDesktopExecArg(tt.givenArg)
+
+			// THEN
+			assert.Equal(t, tt.expectedQuoted, quoted)
+		})
+	}
+}
+
+func getRouterWithDependencies(t *testing.T) (*GameRouter, *MockFileRepository, *MockGameFinder, *MockGameLauncher, *MockGameVersionDetector, *MockServerQuery, *MockHostResolver, *MockLaunchHistory) {
+	ctrl := gomock.NewController(t)
+	mockRepository := NewMockFileRepository(ctrl)
+	mockFinder := NewMockGameFinder(ctrl)
+	mockLauncher := NewMockGameLauncher(ctrl)
+	mockDetector := NewMockGameVersionDetector(ctrl)
+	mockQuery := NewMockServerQuery(ctrl)
+	mockResolver := NewMockHostResolver(ctrl)
+	mockHistory := NewMockLaunchHistory(ctrl)
+	return New(mockRepository, mockFinder, mockLauncher, mockDetector, mockQuery, mockResolver, mockHistory), mockRepository, mockFinder, mockLauncher, mockDetector, mockQuery, mockResolver, mockHistory
+}
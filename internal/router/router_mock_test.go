@@ -7,95 +7,18 @@
 package router
 
 import (
+	context "context"
+	net "net"
 	url "net/url"
 	reflect "reflect"
 
+	history "github.com/cetteup/joinme.click-launcher/internal/history"
 	game_launcher "github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	server_query "github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	software_finder "github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 	gomock "go.uber.org/mock/gomock"
-	registry "golang.org/x/sys/windows/registry"
 )
 
-// MockRegistryRepository is a mock of RegistryRepository interface.
-type MockRegistryRepository struct {
-	ctrl     *gomock.Controller
-	recorder *MockRegistryRepositoryMockRecorder
-}
-
-// MockRegistryRepositoryMockRecorder is the mock recorder for MockRegistryRepository.
-type MockRegistryRepositoryMockRecorder struct {
-	mock *MockRegistryRepository
-}
-
-// NewMockRegistryRepository creates a new mock instance.
-func NewMockRegistryRepository(ctrl *gomock.Controller) *MockRegistryRepository {
-	mock := &MockRegistryRepository{ctrl: ctrl}
-	mock.recorder = &MockRegistryRepositoryMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRegistryRepository) EXPECT() *MockRegistryRepositoryMockRecorder {
-	return m.recorder
-}
-
-// CreateKey mocks base method.
-func (m *MockRegistryRepository) CreateKey(k registry.Key, path string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateKey", k, path)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateKey indicates an expected call of CreateKey.
-func (mr *MockRegistryRepositoryMockRecorder) CreateKey(k, path any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockRegistryRepository)(nil).CreateKey), k, path)
-}
-
-// DeleteKey mocks base method.
-func (m *MockRegistryRepository) DeleteKey(k registry.Key, path string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteKey", k, path)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteKey indicates an expected call of DeleteKey.
-func (mr *MockRegistryRepositoryMockRecorder) DeleteKey(k, path any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*MockRegistryRepository)(nil).DeleteKey), k, path)
-}
-
-// GetStringValue mocks base method.
-func (m *MockRegistryRepository) GetStringValue(k registry.Key, path, valueName string) (string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStringValue", k, path, valueName)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetStringValue indicates an expected call of GetStringValue.
-func (mr *MockRegistryRepositoryMockRecorder) GetStringValue(k, path, valueName any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStringValue", reflect.TypeOf((*MockRegistryRepository)(nil).GetStringValue), k, path, valueName)
-}
-
-// SetStringValue mocks base method.
-func (m *MockRegistryRepository) SetStringValue(k registry.Key, path, valueName, value string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetStringValue", k, path, valueName, value)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// SetStringValue indicates an expected call of SetStringValue.
-func (mr *MockRegistryRepositoryMockRecorder) SetStringValue(k, path, valueName, value any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStringValue", reflect.TypeOf((*MockRegistryRepository)(nil).SetStringValue), k, path, valueName, value)
-}
-
 // MockGameFinder is a mock of GameFinder interface.
 type MockGameFinder struct {
 	ctrl     *gomock.Controller
@@ -203,15 +126,16 @@ func (m *MockGameLauncher) EXPECT() *MockGameLauncherMockRecorder {
 }
 
 // StartGame mocks base method.
-func (m *MockGameLauncher) StartGame(u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType, cmdBuilder game_launcher.CommandBuilder, hookHandlers ...game_launcher.HookHandler) error {
+func (m *MockGameLauncher) StartGame(u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType, cmdBuilder game_launcher.CommandBuilder, hookHandlers ...game_launcher.HookHandler) (game_launcher.LaunchRecord, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{u, config, launchType, cmdBuilder}
 	for _, a := range hookHandlers {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "StartGame", varargs...)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(game_launcher.LaunchRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // StartGame indicates an expected call of StartGame.
@@ -220,3 +144,154 @@ func (mr *MockGameLauncherMockRecorder) StartGame(u, config, launchType, cmdBuil
 	varargs := append([]any{u, config, launchType, cmdBuilder}, hookHandlers...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartGame", reflect.TypeOf((*MockGameLauncher)(nil).StartGame), varargs...)
 }
+
+// MockGameVersionDetector is a mock of GameVersionDetector interface.
+type MockGameVersionDetector struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameVersionDetectorMockRecorder
+}
+
+// MockGameVersionDetectorMockRecorder is the mock recorder for MockGameVersionDetector.
+type MockGameVersionDetectorMockRecorder struct {
+	mock *MockGameVersionDetector
+}
+
+// NewMockGameVersionDetector creates a new mock instance.
+func NewMockGameVersionDetector(ctrl *gomock.Controller) *MockGameVersionDetector {
+	mock := &MockGameVersionDetector{ctrl: ctrl}
+	mock.recorder = &MockGameVersionDetectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGameVersionDetector) EXPECT() *MockGameVersionDetectorMockRecorder {
+	return m.recorder
+}
+
+// DetectVersion mocks base method.
+func (m *MockGameVersionDetector) DetectVersion(path string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectVersion", path)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectVersion indicates an expected call of DetectVersion.
+func (mr *MockGameVersionDetectorMockRecorder) DetectVersion(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectVersion", reflect.TypeOf((*MockGameVersionDetector)(nil).DetectVersion), path)
+}
+
+// MockServerQuery is a mock of ServerQuery interface.
+type MockServerQuery struct {
+	ctrl     *gomock.Controller
+	recorder *MockServerQueryMockRecorder
+}
+
+// MockServerQueryMockRecorder is the mock recorder for MockServerQuery.
+type MockServerQueryMockRecorder struct {
+	mock *MockServerQuery
+}
+
+// NewMockServerQuery creates a new mock instance.
+func NewMockServerQuery(ctrl *gomock.Controller) *MockServerQuery {
+	mock := &MockServerQuery{ctrl: ctrl}
+	mock.recorder = &MockServerQueryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServerQuery) EXPECT() *MockServerQueryMockRecorder {
+	return m.recorder
+}
+
+// Query mocks base method.
+func (m *MockServerQuery) Query(config server_query.Config, host string, gamePort int) (*server_query.ServerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", config, host, gamePort)
+	ret0, _ := ret[0].(*server_query.ServerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockServerQueryMockRecorder) Query(config, host, gamePort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockServerQuery)(nil).Query), config, host, gamePort)
+}
+
+// MockHostResolver is a mock of HostResolver interface.
+type MockHostResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockHostResolverMockRecorder
+}
+
+// MockHostResolverMockRecorder is the mock recorder for MockHostResolver.
+type MockHostResolverMockRecorder struct {
+	mock *MockHostResolver
+}
+
+// NewMockHostResolver creates a new mock instance.
+func NewMockHostResolver(ctrl *gomock.Controller) *MockHostResolver {
+	mock := &MockHostResolver{ctrl: ctrl}
+	mock.recorder = &MockHostResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHostResolver) EXPECT() *MockHostResolverMockRecorder {
+	return m.recorder
+}
+
+// LookupIP mocks base method.
+func (m *MockHostResolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupIP", ctx, network, host)
+	ret0, _ := ret[0].([]net.IP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupIP indicates an expected call of LookupIP.
+func (mr *MockHostResolverMockRecorder) LookupIP(ctx, network, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupIP", reflect.TypeOf((*MockHostResolver)(nil).LookupIP), ctx, network, host)
+}
+
+// MockLaunchHistory is a mock of LaunchHistory interface.
+type MockLaunchHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockLaunchHistoryMockRecorder
+}
+
+// MockLaunchHistoryMockRecorder is the mock recorder for MockLaunchHistory.
+type MockLaunchHistoryMockRecorder struct {
+	mock *MockLaunchHistory
+}
+
+// NewMockLaunchHistory creates a new mock instance.
+func NewMockLaunchHistory(ctrl *gomock.Controller) *MockLaunchHistory {
+	mock := &MockLaunchHistory{ctrl: ctrl}
+	mock.recorder = &MockLaunchHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLaunchHistory) EXPECT() *MockLaunchHistoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockLaunchHistory) Append(event history.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockLaunchHistoryMockRecorder) Append(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockLaunchHistory)(nil).Append), event)
+}
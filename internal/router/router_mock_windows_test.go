@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: router_windows.go
+//
+// Generated by this command:
+//
+//	mockgen -source=router_windows.go -destination=router_mock_windows_test.go -package=router -write_package_comment=false
+package router
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	registry "golang.org/x/sys/windows/registry"
+)
+
+// MockRegistryRepository is a mock of RegistryRepository interface.
+type MockRegistryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryRepositoryMockRecorder
+}
+
+// MockRegistryRepositoryMockRecorder is the mock recorder for MockRegistryRepository.
+type MockRegistryRepositoryMockRecorder struct {
+	mock *MockRegistryRepository
+}
+
+// NewMockRegistryRepository creates a new mock instance.
+func NewMockRegistryRepository(ctrl *gomock.Controller) *MockRegistryRepository {
+	mock := &MockRegistryRepository{ctrl: ctrl}
+	mock.recorder = &MockRegistryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistryRepository) EXPECT() *MockRegistryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateKey mocks base method.
+func (m *MockRegistryRepository) CreateKey(k registry.Key, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", k, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockRegistryRepositoryMockRecorder) CreateKey(k, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockRegistryRepository)(nil).CreateKey), k, path)
+}
+
+// DeleteKey mocks base method.
+func (m *MockRegistryRepository) DeleteKey(k registry.Key, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKey", k, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKey indicates an expected call of DeleteKey.
+func (mr *MockRegistryRepositoryMockRecorder) DeleteKey(k, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*MockRegistryRepository)(nil).DeleteKey), k, path)
+}
+
+// GetStringValue mocks base method.
+func (m *MockRegistryRepository) GetStringValue(k registry.Key, path, valueName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStringValue", k, path, valueName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStringValue indicates an expected call of GetStringValue.
+func (mr *MockRegistryRepositoryMockRecorder) GetStringValue(k, path, valueName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStringValue", reflect.TypeOf((*MockRegistryRepository)(nil).GetStringValue), k, path, valueName)
+}
+
+// SetStringValue mocks base method.
+func (m *MockRegistryRepository) SetStringValue(k registry.Key, path, valueName, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStringValue", k, path, valueName, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStringValue indicates an expected call of SetStringValue.
+func (mr *MockRegistryRepositoryMockRecorder) SetStringValue(k, path, valueName, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStringValue", reflect.TypeOf((*MockRegistryRepository)(nil).SetStringValue), k, path, valueName, value)
+}
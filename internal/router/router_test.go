@@ -4,25 +4,30 @@ package router
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"golang.org/x/sys/windows/registry"
 
 	"github.com/cetteup/joinme.click-launcher/internal"
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/internal/history"
 	"github.com/cetteup/joinme.click-launcher/internal/titles"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
 func TestGameRouter_AddTitle(t *testing.T) {
 	t.Run("successfully adds title", func(t *testing.T) {
 		// GIVEN
-		router, _, _, _ := getRouterWithDependencies(t)
+		router, _, _, _, _, _, _, _ := getRouterWithDependencies(t)
 		protocol := "some-game-protocol"
 		title := domain.GameTitle{
 			Name:           "some-game",
@@ -38,7 +43,7 @@ func TestGameRouter_AddTitle(t *testing.T) {
 
 	t.Run("custom config is applied to added title", func(t *testing.T) {
 		// GIVEN
-		router, _, _, _ := getRouterWithDependencies(t)
+		router, _, _, _, _, _, _, _ := getRouterWithDependencies(t)
 		protocol := "some-game-protocol"
 		title := domain.GameTitle{
 			Name:           "some-game",
@@ -73,479 +78,36 @@ func TestGameRouter_AddTitle(t *testing.T) {
 	})
 }
 
-func TestGameRouter_RegisterHandlers(t *testing.T) {
-	t.Run("successfully registers handlers", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		handlerCommand, err := router.getHandlerCommand()
-		require.NoError(t, err)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", registry.ErrNotExist)
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)))
-		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameDefault), gomock.Eq(fmt.Sprintf("URL:%s protocol", title.Name)))
-		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameURLProtocol), gomock.Eq(""))
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell)))
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen)))
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)))
-		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)), gomock.Eq(regValueNameDefault), gomock.Eq(handlerCommand))
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, handlerRegistrationResult{
-			Title:                   title,
-			GameInstalled:           true,
-			PlatformClientInstalled: false,
-			PreviouslyRegistered:    false,
-			Registered:              true,
-			Error:                   nil,
-		}, result[0])
-	})
-
-	t.Run("successfully updates handler command", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		handlerCommand, err := router.getHandlerCommand()
-		require.NoError(t, err)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("not-a-handler-command", nil)
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)))
-		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameDefault), gomock.Eq(fmt.Sprintf("URL:%s protocol", title.Name)))
-		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameURLProtocol), gomock.Eq(""))
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell)))
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen)))
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)))
-		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)), gomock.Eq(regValueNameDefault), gomock.Eq(handlerCommand))
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, handlerRegistrationResult{
-			Title:                   title,
-			GameInstalled:           true,
-			PlatformClientInstalled: false,
-			PreviouslyRegistered:    false,
-			Registered:              true,
-			Error:                   nil,
-		}, result[0])
-	})
-
-	t.Run("checks if required platform client is installed", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			PlatformClient: &domain.PlatformClient{
-				Platform: "some-platform",
-				FinderConfig: software_finder.Config{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-client",
-					RegistryValueName: "some-value-name",
-				},
-			},
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		handlerCommand, err := router.getHandlerCommand()
-		require.NoError(t, err)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockFinder.EXPECT().IsInstalled(gomock.Eq(title.PlatformClient.FinderConfig)).Return(true, nil)
-		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return(handlerCommand, nil)
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, handlerRegistrationResult{
-			Title:                   title,
-			GameInstalled:           true,
-			PlatformClientInstalled: true,
-			PreviouslyRegistered:    true,
-			Registered:              false,
-			Error:                   nil,
-		}, result[0])
-	})
-
-	t.Run("skips game if handler is already registered", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		handlerCommand, err := router.getHandlerCommand()
-		require.NoError(t, err)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return(handlerCommand, nil)
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, handlerRegistrationResult{
-			Title:                   title,
-			GameInstalled:           true,
-			PlatformClientInstalled: false,
-			PreviouslyRegistered:    true,
-			Registered:              false,
-			Error:                   nil,
-		}, result[0])
-	})
-
-	t.Run("skips game if not installed", func(t *testing.T) {
-		// GIVEN
-		router, _, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(false, nil)
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, handlerRegistrationResult{
-			Title:                   title,
-			GameInstalled:           false,
-			PlatformClientInstalled: false,
-			PreviouslyRegistered:    false,
-			Registered:              false,
-			Error:                   nil,
-		}, result[0])
-	})
-
-	t.Run("skips game if required platform client is not installed", func(t *testing.T) {
-		// GIVEN
-		router, _, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			PlatformClient: &domain.PlatformClient{
-				Platform: "some-platform",
-				FinderConfig: software_finder.Config{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-client",
-					RegistryValueName: "some-value-name",
-				},
-			},
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockFinder.EXPECT().IsInstalled(gomock.Eq(title.PlatformClient.FinderConfig)).Return(false, nil)
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, handlerRegistrationResult{
-			Title:                   title,
-			GameInstalled:           true,
-			PlatformClientInstalled: false,
-			PreviouslyRegistered:    false,
-			Registered:              false,
-			Error:                   nil,
-		}, result[0])
-	})
-
-	t.Run("error if finder encounters an error checking for game", func(t *testing.T) {
-		// GIVEN
-		router, _, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(false, fmt.Errorf("some-error"))
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, title, result[0].Title)
-		require.ErrorContains(t, result[0].Error, "failed to determine whether game is installed")
-	})
-
-	t.Run("error if finder encounters an error checking for platform client", func(t *testing.T) {
-		// GIVEN
-		router, _, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			PlatformClient: &domain.PlatformClient{
-				Platform: "some-platform",
-				FinderConfig: software_finder.Config{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-client",
-					RegistryValueName: "some-value-name",
-				},
-			},
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockFinder.EXPECT().IsInstalled(gomock.Eq(title.PlatformClient.FinderConfig)).Return(false, fmt.Errorf("some-error"))
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, title, result[0].Title)
-		require.ErrorContains(t, result[0].Error, fmt.Sprintf("failed to determine whether required platform (%s) is installed", title.PlatformClient.Platform))
-	})
-
-	t.Run("error if handler registration check fails", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("some-error"))
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, title, result[0].Title)
-		require.ErrorContains(t, result[0].Error, "failed to determine whether handler is registered")
-	})
-
-	t.Run("error if handler registration fails", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, mockFinder, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-			FinderConfigs: []software_finder.Config{
-				{
-					ForType:           software_finder.RegistryFinder,
-					RegistryKey:       software_finder.RegistryKeyLocalMachine,
-					RegistryPath:      "SOFTWARE\\some-game",
-					RegistryValueName: "some-value-name",
-				},
-			},
-		}
-		router.AddTitle(title)
-
-		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
-		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", registry.ErrNotExist)
-		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title))).Return(fmt.Errorf("some-error"))
-
-		// WHEN
-		result := router.RegisterHandlers()
-
-		// THEN
-		assert.Len(t, result, 1)
-		assert.Equal(t, title, result[0].Title)
-		require.ErrorContains(t, result[0].Error, "failed to register as URL protocol handler")
-	})
-}
-
-func TestGameRouter_DeregisterHandlers(t *testing.T) {
-	t.Run("successfully deregisters handlers", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, _, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-		}
-		router.AddTitle(title)
-
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand))
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen))
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell))
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title))
-
-		// WHEN
-		err := router.DeregisterHandlers()
-
-		// THEN
-		assert.NoError(t, err)
-	})
-
-	t.Run("does not fail if keys do not exist", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, _, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-		}
-		router.AddTitle(title)
-
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)).Return(registry.ErrNotExist)
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen)).Return(registry.ErrNotExist)
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell)).Return(registry.ErrNotExist)
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title)).Return(registry.ErrNotExist)
-
-		// WHEN
-		err := router.DeregisterHandlers()
-
-		// THEN
-		assert.NoError(t, err)
-	})
-
-	t.Run("error if key deletion fails", func(t *testing.T) {
-		// GIVEN
-		router, mockRepository, _, _ := getRouterWithDependencies(t)
-
-		title := domain.GameTitle{
-			Name:           "some-name",
-			ProtocolScheme: "some-protocol",
-		}
-		router.AddTitle(title)
-
-		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)).Return(fmt.Errorf("some-error"))
-
-		// WHEN
-		err := router.DeregisterHandlers()
-
-		// THEN
-		assert.ErrorContains(t, err, "failed to deregister handler for some-name (some-protocol): some-error")
-	})
-}
-
 func TestGameRouter_RunURL(t *testing.T) {
 	type test struct {
 		name                string
 		givenTitle          *domain.GameTitle
 		givenCommandLineURL string
-		expect              func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher)
+		expect              func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver)
 		wantTitle           *domain.GameTitle
 		wantErrContains     string
 	}
 
+	abortingBf2 := titles.Bf2
+	abortingBf2.AbortIfServerUnreachable = true
+	abortingBf2.AbortIfServerFull = true
+
+	reportingUT2004 := titles.UT2004
+	reportingUT2004.LaunchReport = true
+	reportingUT2004.LauncherConfig.WaitForExit = true
+
 	tests := []test{
 		{
 			name:                "successfully launches game and joins server",
 			givenCommandLineURL: "bf2://127.0.0.1:16567",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(16567)).Return(&server_query.ServerInfo{
+					Name:       "some-server",
+					NumPlayers: 64,
+					MaxPlayers: 64,
+				}, nil)
 				gameInstallPath := "C:\\Games\\BF2"
 				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
 				finalLaunchConfig := title.LauncherConfig
@@ -564,12 +126,155 @@ func TestGameRouter_RunURL(t *testing.T) {
 			wantTitle:       &titles.Bf2,
 			wantErrContains: "",
 		},
+		{
+			name:                "successfully launches game and joins server if server cannot be queried",
+			givenCommandLineURL: "bf2://127.0.0.1:16567",
+			givenTitle:          &titles.Bf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(16567)).Return(nil, fmt.Errorf("i/o timeout"))
+				gameInstallPath := "C:\\Games\\BF2"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				launcher.EXPECT().StartGame(gomock.Any(), gomock.Any(), gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin), gomock.Any(), gomock.Any())
+			},
+			wantTitle:       &titles.Bf2,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game with server's mod and joins server",
+			givenCommandLineURL: "cod4://127.0.0.1:28960",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(28960)).Return(&server_query.ServerInfo{
+					Name: "some-server",
+					Mod:  "mods/pml220",
+				}, nil)
+				gameInstallPath := "C:\\Games\\CoD4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				finalLaunchConfig := title.LauncherConfig
+				finalLaunchConfig.InstallPath = gameInstallPath
+				finalLaunchConfig.DefaultArgs = []string{"+set", "fs_game", "mods/pml220"}
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "cod4",
+						Host:   "127.0.0.1:28960",
+					}),
+					gomock.Eq(finalLaunchConfig),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game and joins server via hostname",
+			givenCommandLineURL: "cod4://play.example.org:28960",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				resolver.EXPECT().LookupIP(gomock.Any(), gomock.Eq("ip"), gomock.Eq("play.example.org")).Return([]net.IP{net.IPv4(1, 2, 3, 4), net.IPv4(5, 6, 7, 8)}, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("1.2.3.4"), gomock.Eq(28960)).Return(&server_query.ServerInfo{Name: "some-server"}, nil)
+				gameInstallPath := "C:\\Games\\CoD4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				finalLaunchConfig := title.LauncherConfig
+				finalLaunchConfig.InstallPath = gameInstallPath
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "cod4",
+						Host:   "1.2.3.4:28960",
+					}),
+					gomock.Eq(finalLaunchConfig),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game and joins server via hostname resolving to IPv6",
+			givenCommandLineURL: "cod4://play.example.org:28960",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				resolver.EXPECT().LookupIP(gomock.Any(), gomock.Eq("ip"), gomock.Eq("play.example.org")).Return([]net.IP{net.IPv4(1, 2, 3, 4), net.ParseIP("2606:4700:4700::1111")}, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("2606:4700:4700::1111"), gomock.Eq(28960)).Return(&server_query.ServerInfo{Name: "some-server"}, nil)
+				gameInstallPath := "C:\\Games\\CoD4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "cod4",
+						Host:   "[2606:4700:4700::1111]:28960",
+					}),
+					gomock.Any(),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game and joins server via hostname resolving to IPv4 if game does not support IPv6",
+			givenCommandLineURL: "bf2://play.example.org:16567",
+			givenTitle:          &titles.Bf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				resolver.EXPECT().LookupIP(gomock.Any(), gomock.Eq("ip"), gomock.Eq("play.example.org")).Return([]net.IP{net.ParseIP("2606:4700:4700::1111"), net.IPv4(1, 2, 3, 4)}, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("1.2.3.4"), gomock.Eq(16567)).Return(&server_query.ServerInfo{Name: "some-server"}, nil)
+				gameInstallPath := "C:\\Games\\BF2"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "bf2",
+						Host:   "1.2.3.4:16567",
+					}),
+					gomock.Any(),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Bf2,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game and joins server via IPv6",
+			givenCommandLineURL: "cod4://[2606:4700:4700::1111]:28960",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("2606:4700:4700::1111"), gomock.Eq(28960)).Return(&server_query.ServerInfo{Name: "some-server"}, nil)
+				gameInstallPath := "C:\\Games\\CoD4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				finalLaunchConfig := title.LauncherConfig
+				finalLaunchConfig.InstallPath = gameInstallPath
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "cod4",
+						Host:   "[2606:4700:4700::1111]:28960",
+					}),
+					gomock.Eq(finalLaunchConfig),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "",
+		},
 		{
 			name:                "successfully launches game with mod and joins server",
 			givenCommandLineURL: "bf1942://127.0.0.1:14567?mod=xpack1",
 			givenTitle:          &titles.Bf1942,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(14567)).Return(&server_query.ServerInfo{Name: "some-server"}, nil)
 				gameInstallPath := "C:\\Games\\BF1942"
 				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
 				modFinderConfig := title.Mods[0].ComputeFinderConfigs(gameInstallPath)
@@ -592,11 +297,31 @@ func TestGameRouter_RunURL(t *testing.T) {
 			wantTitle:       &titles.Bf1942,
 			wantErrContains: "",
 		},
+		{
+			name:                "successfully launches game with launch report",
+			givenCommandLineURL: "ut2004://act/launch",
+			givenTitle:          &reportingUT2004,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				gameInstallPath := "C:\\Games\\UT2004"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				launcher.EXPECT().StartGame(gomock.Any(), gomock.Any(), gomock.Eq(game_launcher.LaunchTypeLaunchOnly), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType, cmdBuilder game_launcher.CommandBuilder, hookHandlers ...game_launcher.HookHandler) (game_launcher.LaunchRecord, error) {
+						assert.Equal(t, internal.ReportsDirName, filepath.Base(filepath.Dir(config.ReportDir)))
+						assert.True(t, strings.HasPrefix(filepath.Base(config.ReportDir), "ut2004-"))
+						assert.Equal(t, []string{"System/UT2004.log"}, config.LogFiles)
+						assert.True(t, config.WaitForExit)
+						return game_launcher.LaunchRecord{}, nil
+					})
+			},
+			wantTitle:       &reportingUT2004,
+			wantErrContains: "",
+		},
 		{
 			name:                "successfully launches game via action URL",
 			givenCommandLineURL: "bf2://act/launch",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
 				gameInstallPath := "C:\\Games\\BF2"
 				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
@@ -617,17 +342,102 @@ func TestGameRouter_RunURL(t *testing.T) {
 			wantTitle:       &titles.Bf2,
 			wantErrContains: "",
 		},
+		{
+			name:                "successfully launches game and spectates server via action URL",
+			givenCommandLineURL: "bf4://act/spectate?server=1234567890",
+			givenTitle:          &titles.Bf4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				gameInstallPath := "C:\\Games\\BF4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				finalLaunchConfig := title.LauncherConfig
+				finalLaunchConfig.InstallPath = gameInstallPath
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "bf4",
+						Host:   "1234567890",
+					}),
+					gomock.Eq(finalLaunchConfig),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndSpectate),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Bf4,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game and joins server as commander via action URL",
+			givenCommandLineURL: "bf4://act/command?server=1234567890",
+			givenTitle:          &titles.Bf4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				gameInstallPath := "C:\\Games\\BF4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				finalLaunchConfig := title.LauncherConfig
+				finalLaunchConfig.InstallPath = gameInstallPath
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme: "bf4",
+						Host:   "1234567890",
+					}),
+					gomock.Eq(finalLaunchConfig),
+					gomock.Eq(game_launcher.LaunchTypeLaunchAndCommand),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Bf4,
+			wantErrContains: "",
+		},
+		{
+			name:                "successfully launches game and plays back demo via action URL",
+			givenCommandLineURL: "cod4://act/demo?name=match1",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				gameInstallPath := "C:\\Games\\CoD4"
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
+				finalLaunchConfig := title.LauncherConfig
+				finalLaunchConfig.InstallPath = gameInstallPath
+				launcher.EXPECT().StartGame(
+					gomock.Eq(&url.URL{
+						Scheme:   "cod4",
+						Host:     "act",
+						Path:     "/demo",
+						RawQuery: "name=match1",
+					}),
+					gomock.Eq(finalLaunchConfig),
+					gomock.Eq(game_launcher.LaunchTypePlayDemo),
+					gomock.Any(),
+					gomock.Any(),
+				)
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "",
+		},
+		{
+			name:                "error for demo action URL with invalid demo name",
+			givenCommandLineURL: "cod4://act/demo?name=../config_mp",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "url name is not a valid demo name: ../config_mp",
+		},
 		{
 			name:                "error for unsupported game",
 			givenCommandLineURL: "not-a-supported-game://127.0.0.1:16567",
-			expect:              func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {},
-			wantErrContains:     "game not supported",
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+			},
+			wantErrContains: "game not supported",
 		},
 		{
 			name:                "error for unsupported mod",
 			givenCommandLineURL: "bf2://127.0.0.1:16567?mod=not-a-supported-mod",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
 			},
 			wantTitle:       &titles.Bf2,
@@ -637,17 +447,47 @@ func TestGameRouter_RunURL(t *testing.T) {
 			name:                "error for unsupported action",
 			givenCommandLineURL: "bf2://act/not-a-supported-action",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
 			},
 			wantTitle:       &titles.Bf2,
 			wantErrContains: "action not supported",
 		},
+		{
+			name:                "error for action not supported by game",
+			givenCommandLineURL: "bf1://act/command?server=1234567890",
+			givenTitle:          &titles.Bf1,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+			},
+			wantTitle:       &titles.Bf1,
+			wantErrContains: "action not supported by Battlefield 1: command",
+		},
+		{
+			name:                "error for spectate action URL without server",
+			givenCommandLineURL: "bf4://act/spectate",
+			givenTitle:          &titles.Bf4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+			},
+			wantTitle:       &titles.Bf4,
+			wantErrContains: "server is missing from url query",
+		},
+		{
+			name:                "error for spectate action URL with invalid server",
+			givenCommandLineURL: "bf4://act/spectate?server=not-a-game-id",
+			givenTitle:          &titles.Bf4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+			},
+			wantTitle:       &titles.Bf4,
+			wantErrContains: "url hostname is not a valid game id",
+		},
 		{
 			name:                "error for non-installed game",
 			givenCommandLineURL: "bf2://127.0.0.1:16567",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(false, nil)
 			},
 			wantTitle:       &titles.Bf2,
@@ -657,7 +497,7 @@ func TestGameRouter_RunURL(t *testing.T) {
 			name:                "error for non-installed mod",
 			givenCommandLineURL: "bf2://127.0.0.1:16567?mod=xpack",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
 				gameInstallPath := "C:\\Games\\BF2"
 				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(gameInstallPath, nil)
@@ -670,24 +510,96 @@ func TestGameRouter_RunURL(t *testing.T) {
 		{
 			name:                "error for non-parseable URL",
 			givenCommandLineURL: "://",
-			expect:              func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {},
-			wantErrContains:     "missing protocol scheme",
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+			},
+			wantErrContains: "missing protocol scheme",
 		},
 		{
 			name:                "error for invalid ip:port URL",
 			givenCommandLineURL: "bf2://127.0.0.1",
 			givenTitle:          &titles.Bf2,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
 			},
 			wantTitle:       &titles.Bf2,
 			wantErrContains: "port is missing from url",
 		},
+		{
+			name:                "error for unreachable server if aborting is enabled",
+			givenCommandLineURL: "bf2://127.0.0.1:16567",
+			givenTitle:          &abortingBf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(16567)).Return(nil, fmt.Errorf("i/o timeout"))
+			},
+			wantTitle:       &abortingBf2,
+			wantErrContains: "server is unreachable: i/o timeout",
+		},
+		{
+			name:                "successfully launches Call of Duty game for unreachable server by default",
+			givenCommandLineURL: "cod4://127.0.0.1:28960",
+			givenTitle:          &titles.Cod4,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(28960)).Return(nil, fmt.Errorf("connection refused"))
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return("C:\\Games\\CoD4", nil)
+				launcher.EXPECT().StartGame(gomock.Any(), gomock.Any(), gomock.Eq(game_launcher.LaunchTypeLaunchAndJoin), gomock.Any(), gomock.Any())
+			},
+			wantTitle:       &titles.Cod4,
+			wantErrContains: "",
+		},
+		{
+			name:                "error for full server if aborting is enabled",
+			givenCommandLineURL: "bf2://127.0.0.1:16567",
+			givenTitle:          &abortingBf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				query.EXPECT().Query(gomock.Eq(*title.QueryConfig), gomock.Eq("127.0.0.1"), gomock.Eq(16567)).Return(&server_query.ServerInfo{
+					Name:       "some-server",
+					NumPlayers: 64,
+					MaxPlayers: 64,
+				}, nil)
+			},
+			wantTitle:       &abortingBf2,
+			wantErrContains: "server is full: 64/64 players",
+		},
+		{
+			name:                "error for hostname which cannot be resolved",
+			givenCommandLineURL: "bf2://play.example.org:16567",
+			givenTitle:          &titles.Bf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				resolver.EXPECT().LookupIP(gomock.Any(), gomock.Eq("ip"), gomock.Eq("play.example.org")).Return(nil, fmt.Errorf("no such host"))
+			},
+			wantTitle:       &titles.Bf2,
+			wantErrContains: "failed to resolve server hostname play.example.org: no such host",
+		},
+		{
+			name:                "error for hostname resolving to IPv6 only if game does not support IPv6",
+			givenCommandLineURL: "bf2://play.example.org:16567",
+			givenTitle:          &titles.Bf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+				resolver.EXPECT().LookupIP(gomock.Any(), gomock.Eq("ip"), gomock.Eq("play.example.org")).Return([]net.IP{net.ParseIP("2606:4700:4700::1111")}, nil)
+			},
+			wantTitle:       &titles.Bf2,
+			wantErrContains: "server hostname did not resolve to any supported IP address: play.example.org",
+		},
+		{
+			name:                "error for IPv6 server if game does not support IPv6",
+			givenCommandLineURL: "bf2://[2606:4700:4700::1111]:16567",
+			givenTitle:          &titles.Bf2,
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
+				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+			},
+			wantTitle:       &titles.Bf2,
+			wantErrContains: "this game does not support IPv6 servers",
+		},
 		{
 			name:                "error for invalid gameid URL",
 			givenCommandLineURL: "bf4://not-a-game-id",
 			givenTitle:          &titles.Bf4,
-			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher) {
+			expect: func(title *domain.GameTitle, finder *MockGameFinder, launcher *MockGameLauncher, query *MockServerQuery, resolver *MockHostResolver) {
 				finder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
 			},
 			wantTitle:       &titles.Bf4,
@@ -698,13 +610,14 @@ func TestGameRouter_RunURL(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// GIVEN
-			router, _, mockFinder, mockLauncher := getRouterWithDependencies(t)
+			router, _, mockFinder, mockLauncher, _, mockQuery, mockResolver, mockHistory := getRouterWithDependencies(t)
 			if tt.givenTitle != nil {
 				router.AddTitle(*tt.givenTitle)
 			}
 
 			// EXPECT
-			tt.expect(tt.givenTitle, mockFinder, mockLauncher)
+			tt.expect(tt.givenTitle, mockFinder, mockLauncher, mockQuery, mockResolver)
+			mockHistory.EXPECT().Append(gomock.Any()).Return(nil)
 
 			// WHEN
 			title, err := router.RunURL(tt.givenCommandLineURL)
@@ -722,10 +635,226 @@ func TestGameRouter_RunURL(t *testing.T) {
 	}
 }
 
-func getRouterWithDependencies(t *testing.T) (*GameRouter, *MockRegistryRepository, *MockGameFinder, *MockGameLauncher) {
-	ctrl := gomock.NewController(t)
-	mockRepository := NewMockRegistryRepository(ctrl)
-	mockFinder := NewMockGameFinder(ctrl)
-	mockLauncher := NewMockGameLauncher(ctrl)
-	return New(mockRepository, mockFinder, mockLauncher), mockRepository, mockFinder, mockLauncher
+func TestGameRouter_RunURL_RedactsPasswordInParseError(t *testing.T) {
+	// GIVEN
+	router, _, _, _, _, _, _, mockHistory := getRouterWithDependencies(t)
+	router.AddTitle(titles.Cod4)
+
+	// EXPECT
+	mockHistory.EXPECT().Append(gomock.Any()).DoAndReturn(func(event history.Event) error {
+		assert.Equal(t, "cod4://1.2.3.4:port?password=xxxxx", event.URL)
+		assert.Equal(t, history.OutcomeFailed, event.Outcome)
+		assert.NotContains(t, event.Error, "secret")
+		return nil
+	})
+
+	// WHEN
+	title, err := router.RunURL("cod4://1.2.3.4:port?password=secret")
+
+	// THEN
+	assert.Nil(t, title)
+	require.ErrorContains(t, err, "password=xxxxx")
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestGameRouter_RunURL_RecordsLaunchInHistory(t *testing.T) {
+	type test struct {
+		name                string
+		givenCommandLineURL string
+		givenRecord         game_launcher.LaunchRecord
+		givenErr            error
+		expectedEvent       history.Event
+	}
+
+	exitCode := 0
+	tests := []test{
+		{
+			name:                "records supervised launch with hooks",
+			givenCommandLineURL: "cod4://act/launch?password=secret",
+			givenRecord: game_launcher.LaunchRecord{
+				Args: []string{"iw3mp.exe", "+password", "xxxxx"},
+				Hooks: []game_launcher.HookRecord{
+					{Handler: "kill-process", When: game_launcher.HookWhenPreLaunch, Duration: 1500 * time.Millisecond},
+					{Handler: "some-hook", When: game_launcher.HookWhenPostExit, Duration: 20 * time.Millisecond, Err: fmt.Errorf("some-error")},
+				},
+				Relaunches: 1,
+				Exit:       &game_launcher.GameExit{ExitCode: 0, Runtime: 90 * time.Minute},
+			},
+			expectedEvent: history.Event{
+				Scheme:      "cod4",
+				URL:         "cod4://act/launch?password=xxxxx",
+				Action:      "launch",
+				InstallPath: "C:\\Games\\CoD4",
+				Args:        []string{"iw3mp.exe", "+password", "xxxxx"},
+				Hooks: []history.HookRun{
+					{Handler: "kill-process", When: "pre-launch", DurationMs: 1500},
+					{Handler: "some-hook", When: "post-exit", DurationMs: 20, Error: "some-error"},
+				},
+				Relaunches: 1,
+				ExitCode:   &exitCode,
+				RuntimeMs:  5400000,
+				Outcome:    history.OutcomeExited,
+			},
+		},
+		{
+			name:                "records dry run",
+			givenCommandLineURL: "cod4://127.0.0.1:28960",
+			givenRecord: game_launcher.LaunchRecord{
+				Args:   []string{"iw3mp.exe", "+connect", "127.0.0.1:28960"},
+				DryRun: true,
+			},
+			expectedEvent: history.Event{
+				Scheme:      "cod4",
+				URL:         "cod4://127.0.0.1:28960",
+				Action:      "join",
+				InstallPath: "C:\\Games\\CoD4",
+				Args:        []string{"iw3mp.exe", "+connect", "127.0.0.1:28960"},
+				Outcome:     history.OutcomeDryRun,
+			},
+		},
+		{
+			name:                "records failed launch",
+			givenCommandLineURL: "cod4://127.0.0.1:28960",
+			givenRecord: game_launcher.LaunchRecord{
+				Hooks: []game_launcher.HookRecord{
+					{Handler: "kill-process", When: game_launcher.HookWhenPreLaunch, Err: fmt.Errorf("access denied")},
+				},
+			},
+			givenErr: fmt.Errorf("failed to run hook kill-process: access denied"),
+			expectedEvent: history.Event{
+				Scheme:      "cod4",
+				URL:         "cod4://127.0.0.1:28960",
+				Action:      "join",
+				InstallPath: "C:\\Games\\CoD4",
+				Hooks: []history.HookRun{
+					{Handler: "kill-process", When: "pre-launch", Error: "access denied"},
+				},
+				Outcome: history.OutcomeFailed,
+				Error:   "failed to run hook kill-process: access denied",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			router, _, mockFinder, mockLauncher, _, mockQuery, _, mockHistory := getRouterWithDependencies(t)
+			router.AddTitle(titles.Cod4)
+
+			// EXPECT
+			mockFinder.EXPECT().IsInstalledAnywhere(gomock.Any()).Return(true, nil)
+			mockFinder.EXPECT().GetInstallDirFromSomewhere(gomock.Any()).Return("C:\\Games\\CoD4", nil)
+			mockQuery.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&server_query.ServerInfo{Name: "some-server", MaxPlayers: 24}, nil).AnyTimes()
+			mockLauncher.EXPECT().StartGame(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.givenRecord, tt.givenErr)
+			mockHistory.EXPECT().Append(gomock.Any()).DoAndReturn(func(event history.Event) error {
+				assert.False(t, event.Timestamp.IsZero())
+				event.Timestamp = time.Time{}
+				assert.Equal(t, tt.expectedEvent, event)
+				return nil
+			})
+
+			// WHEN
+			_, err := router.RunURL(tt.givenCommandLineURL)
+
+			// THEN
+			if tt.givenErr != nil {
+				require.ErrorIs(t, err, tt.givenErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGameRouter_ensureGameVersionIsSupported(t *testing.T) {
+	type test struct {
+		name            string
+		givenTitle      domain.GameTitle
+		expect          func(finder *MockGameFinder, detector *MockGameVersionDetector)
+		wantErrContains string
+	}
+
+	installPath := filepath.FromSlash("/games/some-game")
+	title := domain.GameTitle{
+		Name:           "some-game",
+		ProtocolScheme: "some-game-protocol",
+		FinderConfigs: []software_finder.Config{
+			{
+				ForType:     software_finder.PathFinder,
+				InstallPath: installPath,
+				PathType:    software_finder.PathTypeDir,
+			},
+		},
+		LauncherConfig: game_launcher.Config{
+			ExecutableName: "game.exe",
+			ExecutablePath: "bin\\win32",
+		},
+	}
+	withMinimumVersion := func(title domain.GameTitle, version string) domain.GameTitle {
+		title.MinimumVersion = version
+		return title
+	}
+	executablePath := filepath.Join(installPath, "bin", "win32", "game.exe")
+
+	tests := []test{
+		{
+			name:       "successfully accepts game with minimum version",
+			givenTitle: withMinimumVersion(title, "1.5"),
+			expect: func(finder *MockGameFinder, detector *MockGameVersionDetector) {
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(installPath, nil)
+				detector.EXPECT().DetectVersion(gomock.Eq(executablePath)).Return("1.5.0.0", nil)
+			},
+		},
+		{
+			name:       "successfully accepts game with newer version",
+			givenTitle: withMinimumVersion(title, "1.5"),
+			expect: func(finder *MockGameFinder, detector *MockGameVersionDetector) {
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(installPath, nil)
+				detector.EXPECT().DetectVersion(gomock.Eq(executablePath)).Return("1.10", nil)
+			},
+		},
+		{
+			name:       "does not detect version if title does not have a minimum version",
+			givenTitle: title,
+			expect:     func(finder *MockGameFinder, detector *MockGameVersionDetector) {},
+		},
+		{
+			name:       "error for outdated game",
+			givenTitle: withMinimumVersion(title, "1.5"),
+			expect: func(finder *MockGameFinder, detector *MockGameVersionDetector) {
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(installPath, nil)
+				detector.EXPECT().DetectVersion(gomock.Eq(executablePath)).Return("1.4.1", nil)
+			},
+			wantErrContains: "game needs patch 1.5 (installed version: 1.4.1)",
+		},
+		{
+			name:       "error if version cannot be detected",
+			givenTitle: withMinimumVersion(title, "1.5"),
+			expect: func(finder *MockGameFinder, detector *MockGameVersionDetector) {
+				finder.EXPECT().GetInstallDirFromSomewhere(gomock.Eq(title.FinderConfigs)).Return(installPath, nil)
+				detector.EXPECT().DetectVersion(gomock.Eq(executablePath)).Return("", fmt.Errorf("executable does not contain version info"))
+			},
+			wantErrContains: "failed to determine game version: executable does not contain version info",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			router, _, mockFinder, _, mockDetector, _, _, _ := getRouterWithDependencies(t)
+
+			// EXPECT
+			tt.expect(mockFinder, mockDetector)
+
+			// WHEN
+			err := router.ensureGameVersionIsSupported(tt.givenTitle)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
 }
@@ -0,0 +1,118 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+)
+
+const (
+	regPathSoftware         = "SOFTWARE"
+	regPathClasses          = "Classes"
+	regPathOpen             = "open"
+	regPathShell            = "shell"
+	regPathCommand          = "command"
+	regValueNameDefault     = ""
+	regValueNameURLProtocol = "URL Protocol"
+)
+
+type RegistryRepository interface {
+	GetStringValue(k registry.Key, path string, valueName string) (string, error)
+	SetStringValue(k registry.Key, path string, valueName string, value string) error
+	CreateKey(k registry.Key, path string) error
+	DeleteKey(k registry.Key, path string) error
+}
+
+// URL protocol handlers are registered in the Windows registry
+type handlerRepository = RegistryRepository
+
+func (r *GameRouter) isHandlerRegistered(gameTitle domain.GameTitle) (bool, error) {
+	path := r.getUrlHandlerRegistryPath(gameTitle, regPathShell, regPathOpen, regPathCommand)
+	value, err := r.repository.GetStringValue(registry.CURRENT_USER, path, regValueNameDefault)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	expected, err := r.getHandlerCommand()
+	if err != nil {
+		return false, err
+	}
+
+	return value == expected, nil
+}
+
+func (r *GameRouter) registerHandler(gameTitle domain.GameTitle) error {
+	basePath := r.getUrlHandlerRegistryPath(gameTitle)
+	err := r.repository.CreateKey(registry.CURRENT_USER, basePath)
+	if err != nil {
+		return err
+	}
+
+	err = r.repository.SetStringValue(registry.CURRENT_USER, basePath, regValueNameDefault, fmt.Sprintf("URL:%s protocol", gameTitle.Name))
+	if err != nil {
+		return err
+	}
+	err = r.repository.SetStringValue(registry.CURRENT_USER, basePath, regValueNameURLProtocol, "")
+	if err != nil {
+		return err
+	}
+
+	subKeys := []string{regPathShell, regPathOpen, regPathCommand}
+	for i := range subKeys {
+		subPath := r.getUrlHandlerRegistryPath(gameTitle, subKeys[:i+1]...)
+		err = r.repository.CreateKey(registry.CURRENT_USER, subPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmdPath := r.getUrlHandlerRegistryPath(gameTitle, subKeys...)
+	cmd, err := r.getHandlerCommand()
+	if err != nil {
+		return err
+	}
+
+	return r.repository.SetStringValue(registry.CURRENT_USER, cmdPath, regValueNameDefault, cmd)
+}
+
+func (r *GameRouter) deregisterHandler(gameTitle domain.GameTitle) error {
+	keys := []string{r.getUrlHandlerRegistryPath(gameTitle)}
+	subKeys := []string{regPathShell, regPathOpen, regPathCommand}
+	for i := range subKeys {
+		// We need to delete keys in reverse/"descending" order, so prepend to list
+		keys = append([]string{r.getUrlHandlerRegistryPath(gameTitle, subKeys[:i+1]...)}, keys...)
+	}
+
+	for _, key := range keys {
+		err := r.repository.DeleteKey(registry.CURRENT_USER, key)
+		if err != nil && !errors.Is(err, registry.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GameRouter) getUrlHandlerRegistryPath(gameTitle domain.GameTitle, children ...string) string {
+	path := filepath.Join(regPathSoftware, regPathClasses, gameTitle.ProtocolScheme)
+	for _, child := range children {
+		path = filepath.Join(path, child)
+	}
+
+	return path
+}
+
+func (r *GameRouter) getHandlerCommand() (string, error) {
+	launcherPath, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\"%s\" \"%%1\"", launcherPath), nil
+}
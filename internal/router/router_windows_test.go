@@ -0,0 +1,484 @@
+//go:build unit
+
+package router
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+)
+
+func TestGameRouter_RegisterHandlers(t *testing.T) {
+	t.Run("successfully registers handlers", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		handlerCommand, err := router.getHandlerCommand()
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", registry.ErrNotExist)
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)))
+		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameDefault), gomock.Eq(fmt.Sprintf("URL:%s protocol", title.Name)))
+		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameURLProtocol), gomock.Eq(""))
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell)))
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen)))
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)))
+		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)), gomock.Eq(regValueNameDefault), gomock.Eq(handlerCommand))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    false,
+			Registered:              true,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("successfully updates handler command", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		handlerCommand, err := router.getHandlerCommand()
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("not-a-handler-command", nil)
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)))
+		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameDefault), gomock.Eq(fmt.Sprintf("URL:%s protocol", title.Name)))
+		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title)), gomock.Eq(regValueNameURLProtocol), gomock.Eq(""))
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell)))
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen)))
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)))
+		mockRepository.EXPECT().SetStringValue(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)), gomock.Eq(regValueNameDefault), gomock.Eq(handlerCommand))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    false,
+			Registered:              true,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("checks if required platform client is installed", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			PlatformClient: &domain.PlatformClient{
+				Platform: "some-platform",
+				FinderConfig: software_finder.Config{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-client",
+					RegistryValueName: "some-value-name",
+				},
+			},
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		handlerCommand, err := router.getHandlerCommand()
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockFinder.EXPECT().IsInstalled(gomock.Eq(title.PlatformClient.FinderConfig)).Return(true, nil)
+		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return(handlerCommand, nil)
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: true,
+			PreviouslyRegistered:    true,
+			Registered:              false,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("skips game if handler is already registered", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		handlerCommand, err := router.getHandlerCommand()
+		require.NoError(t, err)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return(handlerCommand, nil)
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    true,
+			Registered:              false,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("skips game if not installed", func(t *testing.T) {
+		// GIVEN
+		router, _, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(false, nil)
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           false,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    false,
+			Registered:              false,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("skips game if required platform client is not installed", func(t *testing.T) {
+		// GIVEN
+		router, _, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			PlatformClient: &domain.PlatformClient{
+				Platform: "some-platform",
+				FinderConfig: software_finder.Config{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-client",
+					RegistryValueName: "some-value-name",
+				},
+			},
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockFinder.EXPECT().IsInstalled(gomock.Eq(title.PlatformClient.FinderConfig)).Return(false, nil)
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, handlerRegistrationResult{
+			Title:                   title,
+			GameInstalled:           true,
+			PlatformClientInstalled: false,
+			PreviouslyRegistered:    false,
+			Registered:              false,
+			Error:                   nil,
+		}, result[0])
+	})
+
+	t.Run("error if finder encounters an error checking for game", func(t *testing.T) {
+		// GIVEN
+		router, _, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(false, fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, title, result[0].Title)
+		require.ErrorContains(t, result[0].Error, "failed to determine whether game is installed")
+	})
+
+	t.Run("error if finder encounters an error checking for platform client", func(t *testing.T) {
+		// GIVEN
+		router, _, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			PlatformClient: &domain.PlatformClient{
+				Platform: "some-platform",
+				FinderConfig: software_finder.Config{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-client",
+					RegistryValueName: "some-value-name",
+				},
+			},
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockFinder.EXPECT().IsInstalled(gomock.Eq(title.PlatformClient.FinderConfig)).Return(false, fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, title, result[0].Title)
+		require.ErrorContains(t, result[0].Error, fmt.Sprintf("failed to determine whether required platform (%s) is installed", title.PlatformClient.Platform))
+	})
+
+	t.Run("error if handler registration check fails", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, title, result[0].Title)
+		require.ErrorContains(t, result[0].Error, "failed to determine whether handler is registered")
+	})
+
+	t.Run("error if handler registration fails", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, mockFinder, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+			FinderConfigs: []software_finder.Config{
+				{
+					ForType:           software_finder.RegistryFinder,
+					RegistryKey:       software_finder.RegistryKeyLocalMachine,
+					RegistryPath:      "SOFTWARE\\some-game",
+					RegistryValueName: "some-value-name",
+				},
+			},
+		}
+		router.AddTitle(title)
+
+		mockFinder.EXPECT().IsInstalledAnywhere(gomock.Eq(title.FinderConfigs)).Return(true, nil)
+		mockRepository.EXPECT().GetStringValue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", registry.ErrNotExist)
+		mockRepository.EXPECT().CreateKey(gomock.Eq(registry.CURRENT_USER), gomock.Eq(router.getUrlHandlerRegistryPath(title))).Return(fmt.Errorf("some-error"))
+
+		// WHEN
+		result := router.RegisterHandlers()
+
+		// THEN
+		assert.Len(t, result, 1)
+		assert.Equal(t, title, result[0].Title)
+		require.ErrorContains(t, result[0].Error, "failed to register as URL protocol handler")
+	})
+}
+
+func TestGameRouter_DeregisterHandlers(t *testing.T) {
+	t.Run("successfully deregisters handlers", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, _, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+		}
+		router.AddTitle(title)
+
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand))
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen))
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell))
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title))
+
+		// WHEN
+		err := router.DeregisterHandlers()
+
+		// THEN
+		assert.NoError(t, err)
+	})
+
+	t.Run("does not fail if keys do not exist", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, _, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+		}
+		router.AddTitle(title)
+
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)).Return(registry.ErrNotExist)
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen)).Return(registry.ErrNotExist)
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell)).Return(registry.ErrNotExist)
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title)).Return(registry.ErrNotExist)
+
+		// WHEN
+		err := router.DeregisterHandlers()
+
+		// THEN
+		assert.NoError(t, err)
+	})
+
+	t.Run("error if key deletion fails", func(t *testing.T) {
+		// GIVEN
+		router, mockRepository, _, _, _, _, _, _ := getRouterWithDependencies(t)
+
+		title := domain.GameTitle{
+			Name:           "some-name",
+			ProtocolScheme: "some-protocol",
+		}
+		router.AddTitle(title)
+
+		mockRepository.EXPECT().DeleteKey(gomock.Eq(registry.CURRENT_USER), router.getUrlHandlerRegistryPath(title, regPathShell, regPathOpen, regPathCommand)).Return(fmt.Errorf("some-error"))
+
+		// WHEN
+		err := router.DeregisterHandlers()
+
+		// THEN
+		assert.ErrorContains(t, err, "failed to deregister handler for some-name (some-protocol): some-error")
+	})
+}
+
+func getRouterWithDependencies(t *testing.T) (*GameRouter, *MockRegistryRepository, *MockGameFinder, *MockGameLauncher, *MockGameVersionDetector, *MockServerQuery, *MockHostResolver, *MockLaunchHistory) {
+	ctrl := gomock.NewController(t)
+	mockRepository := NewMockRegistryRepository(ctrl)
+	mockFinder := NewMockGameFinder(ctrl)
+	mockLauncher := NewMockGameLauncher(ctrl)
+	mockDetector := NewMockGameVersionDetector(ctrl)
+	mockQuery := NewMockServerQuery(ctrl)
+	mockResolver := NewMockHostResolver(ctrl)
+	mockHistory := NewMockLaunchHistory(ctrl)
+	return New(mockRepository, mockFinder, mockLauncher, mockDetector, mockQuery, mockResolver, mockHistory), mockRepository, mockFinder, mockLauncher, mockDetector, mockQuery, mockResolver, mockHistory
+}
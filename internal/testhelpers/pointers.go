@@ -0,0 +1,6 @@
+package testhelpers
+
+// Ptr Returns a pointer to the given value (e.g. for optional config values in test cases)
+func Ptr[T any](v T) *T {
+	return &v
+}
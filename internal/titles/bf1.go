@@ -30,6 +30,7 @@ var Bf1 = domain.GameTitle{
 	},
 	URLValidator: internal.MakePatternURLValidator(internal.Frostbite3GameIdPattern),
 	CmdBuilder:   internal.OriginCmdBuilder{},
+	Actions:      []domain.GameAction{internal.ActionSpectate}, // Battlefield 1 does not have a commander role
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
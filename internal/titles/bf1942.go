@@ -6,6 +6,7 @@ import (
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	localinternal "github.com/cetteup/joinme.click-launcher/internal/titles/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
@@ -29,6 +30,11 @@ var Bf1942 = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 1942",
 			RegistryValueName: "GAMEDIR",
 		},
+		{
+			// Fallback for (community) installers not writing the usual registry keys
+			ForType:            software_finder.UninstallFinder,
+			DisplayNamePattern: `(?i)^Battlefield 1942$`,
+		},
 	},
 	Mods: []domain.GameMod{
 		domain.MakeMod(
@@ -121,7 +127,12 @@ var Bf1942 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: localinternal.IPPortURLValidator{},
+	QueryConfig: &server_query.Config{
+		Protocol: server_query.ProtocolGameSpy1,
+		// Default query port is 23000, default game port is 14567
+		PortOffset: 8433,
+	},
+	URLValidator: localinternal.IPPortURLValidator{AllowPassword: true},
 	CmdBuilder:   localinternal.RefractorV1CmdBuilder{},
 	HookHandlers: []game_launcher.HookHandler{
 		localinternal.MakeKillProcessHookHandler(true),
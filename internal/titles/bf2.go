@@ -3,14 +3,13 @@ package titles
 import (
 	"fmt"
 	"net/url"
-
-	"github.com/cetteup/conman/pkg/game/bf2"
-	"github.com/cetteup/conman/pkg/handler"
+	"path/filepath"
 
 	"github.com/cetteup/joinme.click-launcher/internal"
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	localinternal "github.com/cetteup/joinme.click-launcher/internal/titles/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
@@ -21,6 +20,7 @@ const (
 	bf2ModArcticWarfare = "Arctic_Warfare"
 	bf2ModPirates       = "bfp2"
 	bf2ModPoE2          = "poe2"
+	bf2DemoDir          = "demos"
 
 	bf2HookPurgeServerHistory = "purge-server-history"
 	bf2HookPurgeShaderCache   = "purge-shader-cache"
@@ -39,6 +39,11 @@ var Bf2 = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Electronic Arts\\EA Games\\Battlefield 2",
 			RegistryValueName: "InstallDir",
 		},
+		{
+			// Fallback for (community) installers not writing the usual registry keys
+			ForType:            software_finder.UninstallFinder,
+			DisplayNamePattern: `(?i)^Battlefield 2(\(TM\))?$`,
+		},
 	},
 	Mods: []domain.GameMod{
 		domain.MakeMod(
@@ -117,55 +122,44 @@ var Bf2 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: localinternal.IPPortURLValidator{},
-	CmdBuilder:   bf2CmdBuilder{},
-	HookHandlers: []game_launcher.HookHandler{
-		localinternal.MakeKillProcessHookHandler(true),
-		bf2SetDefaultProfileHookHandler{},
-		bf2PurgeServerHistoryHookHandler{},
-		bf2PurgeShaderCacheHookHandler{},
-		bf2PurgeLogoCacheHookHandler{},
+	QueryConfig: &server_query.Config{
+		Protocol: server_query.ProtocolGameSpy3,
+		// Default query port is 29900, default game port is 16567
+		PortOffset: 13333,
 	},
+	URLValidator: localinternal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:   bf2CmdBuilder{},
+	Actions:      []domain.GameAction{localinternal.MakeDemoAction(localinternal.DemoQueryKeyFile)},
+	LogFiles:     []string{"logs"},
+	HookHandlers: append(
+		[]game_launcher.HookHandler{localinternal.MakeKillProcessHookHandler(true)},
+		bf2ProfileHookHandlers...,
+	),
 }
 
 type bf2CmdBuilder struct{}
 
-func (b bf2CmdBuilder) GetArgs(fr game_launcher.FileRepository, u *url.URL, launchType game_launcher.LaunchType) ([]string, error) {
-	configHandler := handler.New(fr)
-	profileCon, err := bf2.GetDefaultProfileProfileCon(configHandler)
+func (b bf2CmdBuilder) GetArgs(fr game_launcher.FileRepository, u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	args, err := getBf2ProfileArgs(fr)
 	if err != nil {
 		return nil, err
 	}
 
-	args := make([]string, 0, 12)
-	// Only multiplayer profiles contain an email address
-	if profileCon.HasKey(bf2.ProfileConKeyEmail) {
-		playerName, encryptedPassword, err2 := bf2.GetEncryptedLogin(profileCon)
-		if err2 != nil {
-			return nil, fmt.Errorf("failed to extract login details from profile.con: %s", err)
-		}
-
-		password, err2 := bf2.DecryptProfileConPassword(encryptedPassword)
-		if err2 != nil {
-			return nil, fmt.Errorf("failed to decrypt player password: %s", err)
-		}
-
-		args = append(args, "+playerName", playerName, "+playerPassword", password)
-	} else {
-		// Singleplayer profiles always have an empty GamespyNick, so use the "normal" nick instead
-		playerName, err2 := profileCon.GetValue(bf2.ProfileConKeyNick)
-		if err2 != nil {
-			return nil, fmt.Errorf("failed to extract player name from profile.con: %s", err2)
-		}
-
-		args = append(args, "+playerName", playerName.String())
-	}
-
-	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
+	switch launchType {
+	case game_launcher.LaunchTypeLaunchAndJoin:
 		args = append(args, "+joinServer", u.Hostname(), "+port", u.Port())
+	case game_launcher.LaunchTypePlayDemo:
+		demoArgs, err := getBf2DemoArgs(fr, u, config)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, demoArgs...)
 	}
 
 	query := u.Query()
+	if launchType == game_launcher.LaunchTypeLaunchAndJoin && internal.QueryHasPassword(query) {
+		args = append(args, "+password", internal.GetPasswordFromQuery(query))
+	}
 	if internal.QueryHasMod(query) {
 		args = append(args,
 			"+modPath", fmt.Sprintf("mods/%s", internal.GetModFromQuery(query)),
@@ -176,75 +170,16 @@ func (b bf2CmdBuilder) GetArgs(fr game_launcher.FileRepository, u *url.URL, laun
 	return args, nil
 }
 
-type bf2SetDefaultProfileHookHandler struct{}
-
-func (h bf2SetDefaultProfileHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, args map[string]string) error {
-	profileKey, ok := args[hookArgProfile]
-	if !ok {
-		return fmt.Errorf("required argument %s for hook %s is missing", hookArgProfile, h.String())
-	}
-
-	configHandler := handler.New(fr)
-	globalCon, err := configHandler.ReadGlobalConfig(handler.GameBf2)
+// getBf2DemoArgs Returns the arguments for playing back a battle recorder demo located in the game's demo dir
+func getBf2DemoArgs(fr game_launcher.FileRepository, u *url.URL, config game_launcher.Config) ([]string, error) {
+	file := u.Query().Get(localinternal.DemoQueryKeyFile)
+	exists, err := fr.FileExists(filepath.Join(config.InstallPath, bf2DemoDir, file))
 	if err != nil {
-		return err
-	}
-
-	bf2.SetDefaultProfile(globalCon, profileKey)
-
-	return configHandler.WriteConfigFile(globalCon)
-}
-
-func (h bf2SetDefaultProfileHookHandler) String() string {
-	return bf2HookSetDefaultProfile
-}
-
-type bf2PurgeServerHistoryHookHandler struct{}
-
-func (h bf2PurgeServerHistoryHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, args map[string]string) error {
-	configHandler := handler.New(fr)
-	profileKey, ok := args[hookArgProfile]
-	if !ok {
-		// Use default profile if none has been configured
-		var err error
-		profileKey, err = bf2.GetDefaultProfileKey(configHandler)
-		if err != nil {
-			return err
-		}
+		return nil, fmt.Errorf("failed to look up demo %s: %s", file, err)
 	}
-
-	generalCon, err := bf2.ReadProfileConfigFile(configHandler, profileKey, bf2.ProfileConfigFileGeneralCon)
-	if err != nil {
-		return err
+	if !exists {
+		return nil, fmt.Errorf("demo not found: %s", file)
 	}
 
-	bf2.PurgeServerHistory(generalCon)
-
-	return configHandler.WriteConfigFile(generalCon)
-}
-
-func (h bf2PurgeServerHistoryHookHandler) String() string {
-	return bf2HookPurgeServerHistory
-}
-
-type bf2PurgeShaderCacheHookHandler struct{}
-
-func (h bf2PurgeShaderCacheHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, _ map[string]string) error {
-	configHandler := handler.New(fr)
-	return configHandler.PurgeShaderCache(handler.GameBf2)
-}
-
-func (h bf2PurgeShaderCacheHookHandler) String() string {
-	return bf2HookPurgeShaderCache
-}
-
-type bf2PurgeLogoCacheHookHandler struct{}
-
-func (h bf2PurgeLogoCacheHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, _ map[string]string) error {
-	configHandler := handler.New(fr)
-	return configHandler.PurgeLogoCache(handler.GameBf2)
-}
-
-func (h bf2PurgeLogoCacheHookHandler) String() string {
-	return bf2HookPurgeLogoCache
+	return []string{"+demo", fmt.Sprintf("%s/%s", bf2DemoDir, file)}, nil
 }
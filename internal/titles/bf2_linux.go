@@ -0,0 +1,14 @@
+package titles
+
+import (
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+)
+
+// Profile hooks need to locate the profiles in the Windows documents folder, so they are only available on Windows
+var bf2ProfileHookHandlers []game_launcher.HookHandler
+
+// getBf2ProfileArgs Profile passwords are encrypted using the Windows data protection API, so no login arguments
+// can be provided and the game will prompt for the login instead
+func getBf2ProfileArgs(_ game_launcher.FileRepository) ([]string, error) {
+	return make([]string, 0, 12), nil
+}
@@ -0,0 +1,126 @@
+package titles
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/cetteup/conman/pkg/game/bf2"
+	"github.com/cetteup/conman/pkg/handler"
+
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+)
+
+var bf2ProfileHookHandlers = []game_launcher.HookHandler{
+	bf2SetDefaultProfileHookHandler{},
+	bf2PurgeServerHistoryHookHandler{},
+	bf2PurgeShaderCacheHookHandler{},
+	bf2PurgeLogoCacheHookHandler{},
+}
+
+// getBf2ProfileArgs Returns the login arguments for the default profile
+func getBf2ProfileArgs(fr game_launcher.FileRepository) ([]string, error) {
+	configHandler := handler.New(fr)
+	profileCon, err := bf2.GetDefaultProfileProfileCon(configHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, 12)
+	// Only multiplayer profiles contain an email address
+	if profileCon.HasKey(bf2.ProfileConKeyEmail) {
+		playerName, encryptedPassword, err2 := bf2.GetEncryptedLogin(profileCon)
+		if err2 != nil {
+			return nil, fmt.Errorf("failed to extract login details from profile.con: %s", err)
+		}
+
+		password, err2 := bf2.DecryptProfileConPassword(encryptedPassword)
+		if err2 != nil {
+			return nil, fmt.Errorf("failed to decrypt player password: %s", err)
+		}
+
+		args = append(args, "+playerName", playerName, "+playerPassword", password)
+	} else {
+		// Singleplayer profiles always have an empty GamespyNick, so use the "normal" nick instead
+		playerName, err2 := profileCon.GetValue(bf2.ProfileConKeyNick)
+		if err2 != nil {
+			return nil, fmt.Errorf("failed to extract player name from profile.con: %s", err2)
+		}
+
+		args = append(args, "+playerName", playerName.String())
+	}
+
+	return args, nil
+}
+
+type bf2SetDefaultProfileHookHandler struct{}
+
+func (h bf2SetDefaultProfileHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, args map[string]string) error {
+	profileKey, ok := args[hookArgProfile]
+	if !ok {
+		return fmt.Errorf("required argument %s for hook %s is missing", hookArgProfile, h.String())
+	}
+
+	configHandler := handler.New(fr)
+	globalCon, err := configHandler.ReadGlobalConfig(handler.GameBf2)
+	if err != nil {
+		return err
+	}
+
+	bf2.SetDefaultProfile(globalCon, profileKey)
+
+	return configHandler.WriteConfigFile(globalCon)
+}
+
+func (h bf2SetDefaultProfileHookHandler) String() string {
+	return bf2HookSetDefaultProfile
+}
+
+type bf2PurgeServerHistoryHookHandler struct{}
+
+func (h bf2PurgeServerHistoryHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, args map[string]string) error {
+	configHandler := handler.New(fr)
+	profileKey, ok := args[hookArgProfile]
+	if !ok {
+		// Use default profile if none has been configured
+		var err error
+		profileKey, err = bf2.GetDefaultProfileKey(configHandler)
+		if err != nil {
+			return err
+		}
+	}
+
+	generalCon, err := bf2.ReadProfileConfigFile(configHandler, profileKey, bf2.ProfileConfigFileGeneralCon)
+	if err != nil {
+		return err
+	}
+
+	bf2.PurgeServerHistory(generalCon)
+
+	return configHandler.WriteConfigFile(generalCon)
+}
+
+func (h bf2PurgeServerHistoryHookHandler) String() string {
+	return bf2HookPurgeServerHistory
+}
+
+type bf2PurgeShaderCacheHookHandler struct{}
+
+func (h bf2PurgeShaderCacheHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, _ map[string]string) error {
+	configHandler := handler.New(fr)
+	return configHandler.PurgeShaderCache(handler.GameBf2)
+}
+
+func (h bf2PurgeShaderCacheHookHandler) String() string {
+	return bf2HookPurgeShaderCache
+}
+
+type bf2PurgeLogoCacheHookHandler struct{}
+
+func (h bf2PurgeLogoCacheHookHandler) Run(fr game_launcher.FileRepository, _ *url.URL, _ game_launcher.Config, _ game_launcher.LaunchType, _ map[string]string) error {
+	configHandler := handler.New(fr)
+	return configHandler.PurgeLogoCache(handler.GameBf2)
+}
+
+func (h bf2PurgeLogoCacheHookHandler) String() string {
+	return bf2HookPurgeLogoCache
+}
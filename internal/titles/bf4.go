@@ -30,6 +30,7 @@ var Bf4 = domain.GameTitle{
 	},
 	URLValidator: internal.MakePatternURLValidator(internal.Frostbite3GameIdPattern),
 	CmdBuilder:   internal.OriginCmdBuilder{},
+	Actions:      []domain.GameAction{internal.ActionSpectate, internal.ActionCommand},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
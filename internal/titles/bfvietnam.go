@@ -6,6 +6,7 @@ import (
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	localinternal "github.com/cetteup/joinme.click-launcher/internal/titles/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
@@ -24,6 +25,11 @@ var BfVietnam = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield Vietnam",
 			RegistryValueName: "GAMEDIR",
 		},
+		{
+			// Fallback for (community) installers not writing the usual registry keys
+			ForType:            software_finder.UninstallFinder,
+			DisplayNamePattern: `(?i)^Battlefield Vietnam$`,
+		},
 	},
 	Mods: []domain.GameMod{
 		domain.MakeMod(
@@ -49,7 +55,12 @@ var BfVietnam = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: localinternal.IPPortURLValidator{},
+	QueryConfig: &server_query.Config{
+		Protocol: server_query.ProtocolGameSpy1,
+		// Default query port is 23000, default game port is 15567
+		PortOffset: 7433,
+	},
+	URLValidator: localinternal.IPPortURLValidator{AllowPassword: true},
 	CmdBuilder:   localinternal.RefractorV1CmdBuilder{},
 	HookHandlers: []game_launcher.HookHandler{
 		localinternal.MakeKillProcessHookHandler(true),
@@ -17,6 +17,10 @@ var Cod = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Activision\\Call of Duty",
 			RegistryValueName: "InstallPath",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "2620",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "CoDMP.exe",
@@ -33,8 +37,12 @@ var Cod = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PlusConnectPrefix),
+	QueryConfig:       &internal.CoDQueryConfig,
+	ServerArgsBuilder: internal.CoDServerArgsBuilder{},
+	URLValidator:      internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:        internal.MakeCoDCmdBuilder("main/demos"),
+	Actions:           []domain.GameAction{internal.MakeDemoAction(internal.DemoQueryKeyName)},
+	LogFiles:          []string{"main/console_mp.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 		internal.MakeDeleteFileHookHandler(internal.CoDRunningFilePathsBuilder),
@@ -67,8 +75,12 @@ var CodUO = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PlusConnectPrefix),
+	QueryConfig:       &internal.CoDQueryConfig,
+	ServerArgsBuilder: internal.CoDServerArgsBuilder{},
+	URLValidator:      internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:        internal.MakeCoDCmdBuilder("uo/demos"),
+	Actions:           []domain.GameAction{internal.MakeDemoAction(internal.DemoQueryKeyName)},
+	LogFiles:          []string{"uo/console_mp.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 		internal.MakeDeleteFileHookHandler(internal.CoDRunningFilePathsBuilder),
@@ -17,6 +17,10 @@ var Cod2 = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Activision\\Call of Duty 2",
 			RegistryValueName: "InstallPath",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "2630",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "CoD2MP_s.exe",
@@ -33,8 +37,12 @@ var Cod2 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PlusConnectPrefix),
+	QueryConfig:       &internal.CoDQueryConfig,
+	ServerArgsBuilder: internal.CoDServerArgsBuilder{},
+	URLValidator:      internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:        internal.MakeCoDCmdBuilder("main/demos"),
+	Actions:           []domain.GameAction{internal.MakeDemoAction(internal.DemoQueryKeyName)},
+	LogFiles:          []string{"main/console_mp.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 		internal.MakeDeleteFileHookHandler(internal.CoDRunningFilePathsBuilder),
@@ -17,6 +17,10 @@ var Cod4 = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Activision\\Call of Duty 4",
 			RegistryValueName: "InstallPath",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "7940",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "iw3mp.exe",
@@ -33,8 +37,12 @@ var Cod4 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PlusConnectPrefix),
+	QueryConfig:       &internal.CoDQueryConfig,
+	ServerArgsBuilder: internal.CoDServerArgsBuilder{},
+	URLValidator:      internal.IPPortURLValidator{AllowIPv6: true, AllowPassword: true},
+	CmdBuilder:        internal.MakeCoDCmdBuilder("main/demos"),
+	Actions:           []domain.GameAction{internal.MakeDemoAction(internal.DemoQueryKeyName)},
+	LogFiles:          []string{"main/console_mp.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 		internal.MakeDeleteFileHookHandler(internal.CoDRunningFilePathsBuilder),
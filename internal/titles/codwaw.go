@@ -21,6 +21,10 @@ var CodWaw = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Activision\\Call of Duty WAW",
 			RegistryValueName: "InstallPath",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "10090",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "CoDWaWmp.exe",
@@ -37,8 +41,12 @@ var CodWaw = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PlusConnectPrefix),
+	QueryConfig:       &internal.CoDQueryConfig,
+	ServerArgsBuilder: internal.CoDServerArgsBuilder{},
+	URLValidator:      internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:        internal.MakeCoDCmdBuilder("main/demos"),
+	Actions:           []domain.GameAction{internal.MakeDemoAction(internal.DemoQueryKeyName)},
+	LogFiles:          []string{"main/console_mp.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 		internal.MakeDeleteFileHookHandler(codWawRunningFilePathsBuilder),
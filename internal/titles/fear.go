@@ -23,6 +23,10 @@ var Fear = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Monolith Productions\\FEARCombat\\1.00.0000",
 			RegistryValueName: "InstallDir",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "21090",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "FEARMP.exe",
@@ -34,8 +38,8 @@ var Fear = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder("+join"),
+	URLValidator: internal.IPPortURLValidator{AllowIPv6: true, AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatPlusPassword, "+join"),
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
@@ -62,8 +66,8 @@ var FearSec2 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder("+join"),
+	URLValidator: internal.IPPortURLValidator{AllowIPv6: true, AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatPlusPassword, "+join"),
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
@@ -2,4 +2,4 @@
 
 package titles
 
-//go:generate mockgen -destination=mock_test.go -package=$GOPACKAGE -write_package_comment=false "github.com/cetteup/joinme.click-launcher/pkg/game_launcher" FileRepository
+//go:generate mockgen -destination=mock_test.go -package=$GOPACKAGE -write_package_comment=false "github.com/cetteup/joinme.click-launcher/pkg/game_launcher" FileRepository,ProcessRunner
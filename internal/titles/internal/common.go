@@ -8,11 +8,10 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/mitchellh/go-ps"
-	"github.com/rs/zerolog/log"
-
 	"github.com/cetteup/joinme.click-launcher/internal"
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 )
 
 const (
@@ -20,14 +19,71 @@ const (
 	HookDeleteFile          = "delete-running-file" // CoD games write a dummy file when launched. If the file is still present when launched again, the game assumes it crashed and offers to start in safe mode.
 	PlusConnectPrefix       = "+connect"
 	Frostbite3GameIdPattern = `^\d+$` // game ids vary by length, so for now we are just validating that it only contains numbers
+
+	actionQueryKeyServer = "server"
+
+	DemoQueryKeyName = "name" // Demo name without extension, used by games adding the extension themselves
+	DemoQueryKeyFile = "file" // Demo file name including extension
+)
+
+// Demos must be given by name only (no path), so demo URLs cannot be used to access any files outside the demo dir
+var demoNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-. ]+$`)
+
+var ErrDemoPlaybackNotSupported = fmt.Errorf("this game does not support demo playback")
+
+// ActionSpectate Joins the server given as query param as spectator, e.g. bf4://act/spectate?server=<game id>
+var ActionSpectate = domain.GameAction{
+	Name:             "spectate",
+	LaunchType:       game_launcher.LaunchTypeLaunchAndSpectate,
+	URLValidator:     MakeQueryParamURLValidator(actionQueryKeyServer),
+	ServerURLBuilder: ServerFromQueryURLBuilder,
+}
+
+// ActionCommand Joins the server given as query param as commander, e.g. bf4://act/command?server=<game id>
+var ActionCommand = domain.GameAction{
+	Name:             "command",
+	LaunchType:       game_launcher.LaunchTypeLaunchAndCommand,
+	URLValidator:     MakeQueryParamURLValidator(actionQueryKeyServer),
+	ServerURLBuilder: ServerFromQueryURLBuilder,
+}
+
+// PasswordFormat How a game expects the password of the server to join to be passed on the command line
+type PasswordFormat int
+
+const (
+	// PasswordFormatNone Game cannot join password protected servers via command line
+	PasswordFormatNone PasswordFormat = iota
+	// PasswordFormatPlusPassword Password is passed as separate argument after the server (+password <password>)
+	PasswordFormatPlusPassword
+	// PasswordFormatPlusSetPassword Password cvar is set before connecting (+set password <password>)
+	PasswordFormatPlusSetPassword
+	// PasswordFormatUnrealURL Password is appended to the server as Unreal URL option (<host>:<port>?password=<password>)
+	PasswordFormatUnrealURL
 )
 
-type IPPortURLValidator struct{}
+// unrealURLReservedChars Characters which would start another Unreal URL option, start a fragment or split the argument
+const unrealURLReservedChars = "?# \t\r\n\"'"
+
+// CoDQueryConfig Call of Duty servers answer Quake 3 style queries on the game port
+var CoDQueryConfig = server_query.Config{
+	Protocol: server_query.ProtocolQuake3,
+}
+
+type IPPortURLValidator struct {
+	// Whether the game supports joining servers via IPv6 (given as bracketed address, e.g. [2001:db8::1]:28960)
+	AllowIPv6 bool
+	// Whether the game supports joining password protected servers (password given as query param)
+	AllowPassword bool
+}
 
 func (v IPPortURLValidator) Validate(u *url.URL) error {
 	hostname, port := u.Hostname(), u.Port()
-	if !internal.IsValidIPv4(hostname) {
-		return fmt.Errorf("url hostname is not a valid IPv4 address: %s", hostname)
+	if internal.IsValidIPv6(hostname) {
+		if !v.AllowIPv6 {
+			return fmt.Errorf("this game does not support IPv6 servers: %s", hostname)
+		}
+	} else if !internal.IsValidIPv4(hostname) && !internal.IsValidHostname(hostname) {
+		return fmt.Errorf("url hostname is not a valid IP address or hostname: %s", hostname)
 	}
 	if port == "" {
 		return fmt.Errorf("port is missing from url")
@@ -37,6 +93,9 @@ func (v IPPortURLValidator) Validate(u *url.URL) error {
 	if !internal.IsValidPort(port) {
 		return fmt.Errorf("url port is not a valid network port: %s", port)
 	}
+	if !v.AllowPassword && internal.QueryHasPassword(u.Query()) {
+		return fmt.Errorf("this game does not support joining password protected servers")
+	}
 
 	return nil
 }
@@ -60,111 +119,222 @@ func (v PatternURLValidator) Validate(u *url.URL) error {
 	if !matched {
 		return fmt.Errorf("url hostname is not a valid game id: %s", hostname)
 	}
+	// Games joined via game id prompt for any server password themselves
+	if internal.QueryHasPassword(u.Query()) {
+		return fmt.Errorf("this game does not support joining password protected servers")
+	}
 
 	return nil
 }
 
-func MakeSimpleCmdBuilder(prefixes ...string) SimpleCmdBuilder {
-	return SimpleCmdBuilder{
-		prefixes: prefixes,
+// MakeDemoAction Returns an action playing back the recorded demo given as query param, e.g. cod4://act/demo?name=match1
+func MakeDemoAction(queryKey string) domain.GameAction {
+	return domain.GameAction{
+		Name:         "demo",
+		LaunchType:   game_launcher.LaunchTypePlayDemo,
+		URLValidator: MakeDemoURLValidator(queryKey),
 	}
 }
 
-type SimpleCmdBuilder struct {
-	prefixes []string
+func MakeDemoURLValidator(queryKey string) DemoURLValidator {
+	return DemoURLValidator{
+		queryKey: queryKey,
+	}
 }
 
-func (b SimpleCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, launchType game_launcher.LaunchType) ([]string, error) {
-	args := make([]string, 0, len(b.prefixes))
-	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
-		args = append(args, b.prefixes...)
-		args = append(args, net.JoinHostPort(u.Hostname(), u.Port()))
+type DemoURLValidator struct {
+	queryKey string
+}
+
+func (v DemoURLValidator) Validate(u *url.URL) error {
+	demo := u.Query().Get(v.queryKey)
+	if demo == "" {
+		return fmt.Errorf("%s is missing from url query", v.queryKey)
+	}
+	if !demoNameRegex.MatchString(demo) || strings.Contains(demo, "..") {
+		return fmt.Errorf("url %s is not a valid demo name: %s", v.queryKey, demo)
 	}
 
-	return args, nil
+	return nil
 }
 
-type OriginCmdBuilder struct {
+// MakeQueryParamURLValidator Returns a validator ensuring the URL contains (non-empty) values for all the given query params
+func MakeQueryParamURLValidator(keys ...string) QueryParamURLValidator {
+	return QueryParamURLValidator{
+		keys: keys,
+	}
 }
 
-func (b OriginCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, launchType game_launcher.LaunchType) ([]string, error) {
-	args := make([]string, 0, 8)
-	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
-		args = append(args,
-			"-gameMode", "MP",
-			"-role", "soldier",
-			"-asSpectator", "false",
-			"-gameId", u.Hostname(),
-		)
+type QueryParamURLValidator struct {
+	keys []string
+}
+
+func (v QueryParamURLValidator) Validate(u *url.URL) error {
+	query := u.Query()
+	for _, key := range v.keys {
+		if query.Get(key) == "" {
+			return fmt.Errorf("%s is missing from url query", key)
+		}
 	}
 
-	return args, nil
+	return nil
 }
 
-type RefractorV1CmdBuilder struct{}
+// ServerFromQueryURLBuilder Turns an action URL into the URL of the server given as query param (e.g.
+// bf4://act/spectate?server=123 into bf4://123), so it can be validated and passed to the game like any join URL
+func ServerFromQueryURLBuilder(u *url.URL) *url.URL {
+	return &url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Query().Get(actionQueryKeyServer),
+	}
+}
 
-func (b RefractorV1CmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, launchType game_launcher.LaunchType) ([]string, error) {
-	args := make([]string, 0, 6)
-	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
-		args = append(args, "+joinServer", u.Hostname(), "+port", u.Port())
+func MakeSimpleCmdBuilder(passwordFormat PasswordFormat, prefixes ...string) SimpleCmdBuilder {
+	return SimpleCmdBuilder{
+		passwordFormat: passwordFormat,
+		prefixes:       prefixes,
+	}
+}
+
+type SimpleCmdBuilder struct {
+	passwordFormat PasswordFormat
+	prefixes       []string
+}
+
+func (b SimpleCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, _ game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	if launchType == game_launcher.LaunchTypePlayDemo {
+		return nil, ErrDemoPlaybackNotSupported
 	}
 
+	args := make([]string, 0, len(b.prefixes)+4)
+	if launchType != game_launcher.LaunchTypeLaunchAndJoin {
+		return args, nil
+	}
+
+	server := net.JoinHostPort(u.Hostname(), u.Port())
 	query := u.Query()
-	if internal.QueryHasMod(query) {
-		args = append(args, "+game", internal.GetModFromQuery(query))
+	if !internal.QueryHasPassword(query) {
+		args = append(args, b.prefixes...)
+		return append(args, server), nil
+	}
+
+	password := internal.GetPasswordFromQuery(query)
+	switch b.passwordFormat {
+	case PasswordFormatPlusPassword:
+		args = append(args, b.prefixes...)
+		args = append(args, server, "+password", password)
+	case PasswordFormatPlusSetPassword:
+		args = append(args, "+set", "password", password)
+		args = append(args, b.prefixes...)
+		args = append(args, server)
+	case PasswordFormatUnrealURL:
+		args = append(args, b.prefixes...)
+		// Unreal URL options are not percent-decoded, so passwords need to be passed as is
+		if strings.ContainsAny(password, unrealURLReservedChars) {
+			return nil, fmt.Errorf("server password contains characters which cannot be passed to the game (?, #, whitespace or quotes)")
+		}
+		args = append(args, fmt.Sprintf("%s?password=%s", server, password))
+	default:
+		return nil, fmt.Errorf("this game does not support joining password protected servers")
 	}
 
 	return args, nil
 }
 
-// MakeKillProcessHookHandler Returns a hook handler that kills any running game processes plus any additional targets
-func MakeKillProcessHookHandler(targetLaunchExecutable bool, additionalTargets ...string) KillProcessHookHandler {
-	return KillProcessHookHandler{
-		targetLaunchExecutable: targetLaunchExecutable,
-		additionalTargets:      additionalTargets,
+// MakeCoDCmdBuilder Returns a command builder for Call of Duty titles, which plays back demos from the given dir
+// (relative to the install path, e.g. main/demos)
+func MakeCoDCmdBuilder(demoDir string) CoDCmdBuilder {
+	return CoDCmdBuilder{
+		SimpleCmdBuilder: MakeSimpleCmdBuilder(PasswordFormatPlusSetPassword, PlusConnectPrefix),
+		demoDir:          demoDir,
 	}
 }
 
-type KillProcessHookHandler struct {
-	targetLaunchExecutable bool
-	additionalTargets      []string
+type CoDCmdBuilder struct {
+	SimpleCmdBuilder
+	demoDir string
 }
 
-func (h KillProcessHookHandler) Run(_ game_launcher.FileRepository, _ *url.URL, config game_launcher.Config, _ game_launcher.LaunchType, _ map[string]string) error {
-	targets := h.additionalTargets
-	if h.targetLaunchExecutable {
-		targets = append(targets, config.ExecutableName)
+func (b CoDCmdBuilder) GetArgs(fr game_launcher.FileRepository, u *url.URL, config game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	if launchType != game_launcher.LaunchTypePlayDemo {
+		return b.SimpleCmdBuilder.GetArgs(fr, u, config, launchType)
 	}
 
-	processes, err := ps.Processes()
+	// Demo file extensions contain the game's network protocol version (e.g. .dm_1 or .dm_118), which the game adds
+	// itself, so the demo is passed by name only
+	name := u.Query().Get(DemoQueryKeyName)
+	matches, err := fr.Glob(filepath.Join(config.InstallPath, filepath.FromSlash(b.demoDir), name+".dm_*"))
 	if err != nil {
-		return fmt.Errorf("failed to retrieve process list: %s", err)
-	}
-
-	killed := map[int]string{}
-	for _, process := range processes {
-		if isTargetProcess(targets, process.Executable()) {
-			log.Info().
-				Int("pid", process.Pid()).
-				Str("executable", process.Executable()).
-				Msg("Killing existing game process")
-			if err = killProcess(process.Pid()); err != nil {
-				return fmt.Errorf("failed to kill existing game process %s (%d): %s", process.Executable(), process.Pid(), err)
-			}
-			killed[process.Pid()] = process.Executable()
-		}
+		return nil, fmt.Errorf("failed to look up demo %s: %s", name, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("demo not found: %s", name)
 	}
 
-	// Wait for killed processes to exit
-	if err = waitForProcessesToExit(killed); err != nil {
-		return err
+	return []string{"+demo", name}, nil
+}
+
+type OriginCmdBuilder struct {
+}
+
+func (b OriginCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, _ game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	args := make([]string, 0, 8)
+	var role, asSpectator string
+	switch launchType {
+	case game_launcher.LaunchTypePlayDemo:
+		return nil, ErrDemoPlaybackNotSupported
+	case game_launcher.LaunchTypeLaunchAndJoin:
+		role, asSpectator = "soldier", "false"
+	case game_launcher.LaunchTypeLaunchAndSpectate:
+		role, asSpectator = "spectator", "true"
+	case game_launcher.LaunchTypeLaunchAndCommand:
+		role, asSpectator = "commander", "false"
+	default:
+		return args, nil
 	}
 
-	return nil
+	args = append(args,
+		"-gameMode", "MP",
+		"-role", role,
+		"-asSpectator", asSpectator,
+		"-gameId", u.Hostname(),
+	)
+
+	return args, nil
+}
+
+// CoDServerArgsBuilder Loads the mod (fs_game, e.g. mods/pml220) the server is running, so the game does not need to
+// reconnect after loading it
+type CoDServerArgsBuilder struct{}
+
+func (b CoDServerArgsBuilder) GetServerArgs(info *server_query.ServerInfo) []string {
+	if info.Mod == "" {
+		return nil
+	}
+	return []string{"+set", "fs_game", info.Mod}
 }
 
-func (h KillProcessHookHandler) String() string {
-	return HookKillProcess
+type RefractorV1CmdBuilder struct{}
+
+func (b RefractorV1CmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, _ game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	if launchType == game_launcher.LaunchTypePlayDemo {
+		return nil, ErrDemoPlaybackNotSupported
+	}
+
+	args := make([]string, 0, 6)
+	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
+		args = append(args, "+joinServer", u.Hostname(), "+port", u.Port())
+	}
+
+	query := u.Query()
+	if launchType == game_launcher.LaunchTypeLaunchAndJoin && internal.QueryHasPassword(query) {
+		args = append(args, "+password", internal.GetPasswordFromQuery(query))
+	}
+	if internal.QueryHasMod(query) {
+		args = append(args, "+game", internal.GetModFromQuery(query))
+	}
+
+	return args, nil
 }
 
 func MakeDeleteFileHookHandler(pathsBuilder func(config game_launcher.Config) ([]string, error)) DeleteFileHookHandler {
@@ -200,6 +370,9 @@ func CoDRunningFilePathsBuilder(config game_launcher.Config) ([]string, error) {
 
 	// Primary place to look is in the install path, basically right "next to" the executable
 	primary := filepath.Join(config.InstallPath, name)
+	if !virtualStoreSupported {
+		return []string{primary}, nil
+	}
 
 	// At least when installed in the default location, CoD2 may store the file in the VirtualStore
 	virtualStore, err := buildVirtualStorePath()
@@ -14,13 +14,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
-	"github.com/cetteup/joinme.click-launcher/internal/testhelpers"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 )
 
 func TestIPPortURLValidator(t *testing.T) {
 	type test struct {
 		name            string
+		givenValidator  IPPortURLValidator
 		prepareURL      func(u *url.URL)
 		wantErrContains string
 	}
@@ -31,11 +32,45 @@ func TestIPPortURLValidator(t *testing.T) {
 			prepareURL: func(u *url.URL) {},
 		},
 		{
-			name: "error for non IPv4 hostname",
+			name:           "no error for url containing valid IPv6 and port if IPv6 is allowed",
+			givenValidator: IPPortURLValidator{AllowIPv6: true},
 			prepareURL: func(u *url.URL) {
-				u.Host = "not-an-ipv4-address"
+				u.Host = net.JoinHostPort("2606:4700:4700::1111", u.Port())
 			},
-			wantErrContains: "url hostname is not a valid IPv4 address",
+		},
+		{
+			name: "error for url containing valid IPv6 if IPv6 is not allowed",
+			prepareURL: func(u *url.URL) {
+				u.Host = net.JoinHostPort("2606:4700:4700::1111", u.Port())
+			},
+			wantErrContains: "this game does not support IPv6 servers",
+		},
+		{
+			name:           "no error for url containing password if password is allowed",
+			givenValidator: IPPortURLValidator{AllowPassword: true},
+			prepareURL: func(u *url.URL) {
+				u.RawQuery = "password=secret"
+			},
+		},
+		{
+			name: "error for url containing password if password is not allowed",
+			prepareURL: func(u *url.URL) {
+				u.RawQuery = "password=secret"
+			},
+			wantErrContains: "this game does not support joining password protected servers",
+		},
+		{
+			name: "no error for url containing valid hostname and port",
+			prepareURL: func(u *url.URL) {
+				u.Host = net.JoinHostPort("play.example.org", u.Port())
+			},
+		},
+		{
+			name: "error for neither IPv4 nor hostname",
+			prepareURL: func(u *url.URL) {
+				u.Host = "not_an_ipv4_address"
+			},
+			wantErrContains: "url hostname is not a valid IP address or hostname",
 		},
 		{
 			name: "error for empty port",
@@ -60,10 +95,9 @@ func TestIPPortURLValidator(t *testing.T) {
 				Host: net.JoinHostPort("1.1.1.1", "16567"),
 			}
 			tt.prepareURL(givenURL)
-			validator := IPPortURLValidator{}
 
 			// WHEN
-			err := validator.Validate(givenURL)
+			err := tt.givenValidator.Validate(givenURL)
 
 			// THEN
 			if tt.wantErrContains != "" {
@@ -79,6 +113,7 @@ func TestPatternURLValidator(t *testing.T) {
 	type test struct {
 		name            string
 		givenHost       string
+		givenQuery      string
 		givenPattern    string
 		wantErrContains string
 	}
@@ -101,12 +136,19 @@ func TestPatternURLValidator(t *testing.T) {
 			givenPattern:    Frostbite3GameIdPattern,
 			wantErrContains: "url hostname is not a valid game id",
 		},
+		{
+			name:            "error for url containing password",
+			givenHost:       "1234567890",
+			givenQuery:      "password=secret",
+			givenPattern:    Frostbite3GameIdPattern,
+			wantErrContains: "this game does not support joining password protected servers",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// GIVEN
-			givenUrl := &url.URL{Host: tt.givenHost}
+			givenUrl := &url.URL{Host: tt.givenHost, RawQuery: tt.givenQuery}
 			validator := MakePatternURLValidator(tt.givenPattern)
 
 			// WHEN
@@ -122,13 +164,120 @@ func TestPatternURLValidator(t *testing.T) {
 	}
 }
 
-func TestSimpleCmdBuilder(t *testing.T) {
+func TestDemoURLValidator(t *testing.T) {
 	type test struct {
 		name            string
-		givenHost       string
-		givenPrefixes   []string
-		givenLaunchType game_launcher.LaunchType
-		expectedCmd     []string
+		givenQuery      string
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name:       "no error for valid demo name",
+			givenQuery: "name=match 1.final",
+		},
+		{
+			name:            "error for missing demo name",
+			givenQuery:      "",
+			wantErrContains: "name is missing from url query",
+		},
+		{
+			name:            "error for demo name containing path",
+			givenQuery:      "name=" + url.QueryEscape("../../config"),
+			wantErrContains: "url name is not a valid demo name",
+		},
+		{
+			name:            "error for demo name containing path separator",
+			givenQuery:      "name=" + url.QueryEscape("C:\\demos\\match1"),
+			wantErrContains: "url name is not a valid demo name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			givenUrl := &url.URL{Host: "act", Path: "/demo", RawQuery: tt.givenQuery}
+			validator := MakeDemoURLValidator(DemoQueryKeyName)
+
+			// WHEN
+			err := validator.Validate(givenUrl)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueryParamURLValidator(t *testing.T) {
+	type test struct {
+		name            string
+		givenQuery      string
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name:       "no error for url containing all query params",
+			givenQuery: "server=1234567890&file=demo.dm_1",
+		},
+		{
+			name:            "error for url missing a query param",
+			givenQuery:      "server=1234567890",
+			wantErrContains: "file is missing from url query",
+		},
+		{
+			name:            "error for url containing empty query param",
+			givenQuery:      "server=&file=demo.dm_1",
+			wantErrContains: "server is missing from url query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			givenUrl := &url.URL{Host: "act", Path: "/some-action", RawQuery: tt.givenQuery}
+			validator := MakeQueryParamURLValidator("server", "file")
+
+			// WHEN
+			err := validator.Validate(givenUrl)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServerFromQueryURLBuilder(t *testing.T) {
+	t.Run("returns URL of server given as query param", func(t *testing.T) {
+		// GIVEN
+		givenUrl := &url.URL{Scheme: "bf4", Host: "act", Path: "/spectate", RawQuery: "server=1234567890"}
+
+		// WHEN
+		u := ServerFromQueryURLBuilder(givenUrl)
+
+		// THEN
+		assert.Equal(t, &url.URL{Scheme: "bf4", Host: "1234567890"}, u)
+	})
+}
+
+func TestSimpleCmdBuilder(t *testing.T) {
+	type test struct {
+		name                string
+		givenHost           string
+		givenQuery          string
+		givenPasswordFormat PasswordFormat
+		givenPrefixes       []string
+		givenLaunchType     game_launcher.LaunchType
+		expectedCmd         []string
+		wantErrContains     string
 	}
 
 	tests := []test{
@@ -145,6 +294,69 @@ func TestSimpleCmdBuilder(t *testing.T) {
 			givenPrefixes:   []string{"+connect"},
 			expectedCmd:     []string{"+connect", net.JoinHostPort("1.1.1.1", "16567")},
 		},
+		{
+			name:            "returns bracketed IPv6 host",
+			givenHost:       net.JoinHostPort("2606:4700:4700::1111", "28960"),
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			givenPrefixes:   []string{"+connect"},
+			expectedCmd:     []string{"+connect", "[2606:4700:4700::1111]:28960"},
+		},
+		{
+			name:                "returns command with +password argument",
+			givenHost:           net.JoinHostPort("1.1.1.1", "27888"),
+			givenQuery:          "password=secret",
+			givenPasswordFormat: PasswordFormatPlusPassword,
+			givenPrefixes:       []string{"+join"},
+			givenLaunchType:     game_launcher.LaunchTypeLaunchAndJoin,
+			expectedCmd:         []string{"+join", net.JoinHostPort("1.1.1.1", "27888"), "+password", "secret"},
+		},
+		{
+			name:                "returns command setting password before connecting",
+			givenHost:           net.JoinHostPort("1.1.1.1", "28960"),
+			givenQuery:          "password=secret",
+			givenPasswordFormat: PasswordFormatPlusSetPassword,
+			givenPrefixes:       []string{"+connect"},
+			givenLaunchType:     game_launcher.LaunchTypeLaunchAndJoin,
+			expectedCmd:         []string{"+set", "password", "secret", "+connect", net.JoinHostPort("1.1.1.1", "28960")},
+		},
+		{
+			name:                "returns command with password as Unreal URL option",
+			givenHost:           net.JoinHostPort("1.1.1.1", "7777"),
+			givenQuery:          "password=secret",
+			givenPasswordFormat: PasswordFormatUnrealURL,
+			givenLaunchType:     game_launcher.LaunchTypeLaunchAndJoin,
+			expectedCmd:         []string{"1.1.1.1:7777?password=secret"},
+		},
+		{
+			name:                "returns command with unescaped password as Unreal URL option",
+			givenHost:           net.JoinHostPort("1.1.1.1", "7777"),
+			givenQuery:          "password=" + url.QueryEscape("se&cr%t=1"),
+			givenPasswordFormat: PasswordFormatUnrealURL,
+			givenLaunchType:     game_launcher.LaunchTypeLaunchAndJoin,
+			expectedCmd:         []string{"1.1.1.1:7777?password=se&cr%t=1"},
+		},
+		{
+			name:                "error for password which cannot be passed as Unreal URL option",
+			givenHost:           net.JoinHostPort("1.1.1.1", "7777"),
+			givenQuery:          "password=" + url.QueryEscape("se?cret #1"),
+			givenPasswordFormat: PasswordFormatUnrealURL,
+			givenLaunchType:     game_launcher.LaunchTypeLaunchAndJoin,
+			wantErrContains:     "server password contains characters which cannot be passed to the game",
+		},
+		{
+			name:            "error if launch type is play demo",
+			givenHost:       net.JoinHostPort("1.1.1.1", "7777"),
+			givenLaunchType: game_launcher.LaunchTypePlayDemo,
+			wantErrContains: "this game does not support demo playback",
+		},
+		{
+			name:                "error for password if game does not support passwords",
+			givenHost:           net.JoinHostPort("1.1.1.1", "7777"),
+			givenQuery:          "password=secret",
+			givenPasswordFormat: PasswordFormatNone,
+			givenLaunchType:     game_launcher.LaunchTypeLaunchAndJoin,
+			wantErrContains:     "this game does not support joining password protected servers",
+		},
 		{
 			name:            "returns no arguments if launch type is any but launch and join",
 			givenHost:       net.JoinHostPort("1.1.1.1", "16567"),
@@ -158,11 +370,138 @@ func TestSimpleCmdBuilder(t *testing.T) {
 			// GIVEN
 			ctrl := gomock.NewController(t)
 			mockRepository := NewMockFileRepository(ctrl)
-			u := &url.URL{Host: tt.givenHost}
-			builder := MakeSimpleCmdBuilder(tt.givenPrefixes...)
+			u := &url.URL{Host: tt.givenHost, RawQuery: tt.givenQuery}
+			builder := MakeSimpleCmdBuilder(tt.givenPasswordFormat, tt.givenPrefixes...)
 
 			// WHEN
-			cmd, err := builder.GetArgs(mockRepository, u, tt.givenLaunchType)
+			cmd, err := builder.GetArgs(mockRepository, u, game_launcher.Config{}, tt.givenLaunchType)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCmd, cmd)
+			}
+		})
+	}
+}
+
+func TestCoDCmdBuilder(t *testing.T) {
+	type test struct {
+		name            string
+		givenURL        *url.URL
+		givenLaunchType game_launcher.LaunchType
+		expect          func(fr *MockFileRepository)
+		expectedCmd     []string
+		wantErrContains string
+	}
+
+	installPath := filepath.FromSlash("/games/cod4")
+	demoPattern := filepath.Join(installPath, "main", "demos", "match1.dm_*")
+	demoURL := &url.URL{Scheme: "cod4", Host: "act", Path: "/demo", RawQuery: "name=match1"}
+	tests := []test{
+		{
+			name:            "returns connect command if launch type is launch and join",
+			givenURL:        &url.URL{Host: net.JoinHostPort("1.1.1.1", "28960"), RawQuery: "password=secret"},
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          func(fr *MockFileRepository) {},
+			expectedCmd:     []string{"+set", "password", "secret", "+connect", net.JoinHostPort("1.1.1.1", "28960")},
+		},
+		{
+			name:            "returns demo command if launch type is play demo",
+			givenURL:        demoURL,
+			givenLaunchType: game_launcher.LaunchTypePlayDemo,
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(gomock.Eq(demoPattern)).Return([]string{filepath.Join(installPath, "main", "demos", "match1.dm_1")}, nil)
+			},
+			expectedCmd: []string{"+demo", "match1"},
+		},
+		{
+			name:            "error for non-existing demo",
+			givenURL:        demoURL,
+			givenLaunchType: game_launcher.LaunchTypePlayDemo,
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(gomock.Eq(demoPattern)).Return(nil, nil)
+			},
+			wantErrContains: "demo not found: match1",
+		},
+		{
+			name:            "error for failed demo lookup",
+			givenURL:        demoURL,
+			givenLaunchType: game_launcher.LaunchTypePlayDemo,
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(gomock.Eq(demoPattern)).Return(nil, fmt.Errorf("some-error"))
+			},
+			wantErrContains: "failed to look up demo match1: some-error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			config := game_launcher.Config{InstallPath: installPath}
+			builder := MakeCoDCmdBuilder("main/demos")
+
+			// EXPECT
+			tt.expect(mockRepository)
+
+			// WHEN
+			cmd, err := builder.GetArgs(mockRepository, tt.givenURL, config, tt.givenLaunchType)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCmd, cmd)
+			}
+		})
+	}
+}
+
+func TestOriginCmdBuilder(t *testing.T) {
+	type test struct {
+		name            string
+		givenLaunchType game_launcher.LaunchType
+		expectedCmd     []string
+	}
+
+	tests := []test{
+		{
+			name:            "joins as soldier if launch type is launch and join",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expectedCmd:     []string{"-gameMode", "MP", "-role", "soldier", "-asSpectator", "false", "-gameId", "1234567890"},
+		},
+		{
+			name:            "joins as spectator if launch type is launch and spectate",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndSpectate,
+			expectedCmd:     []string{"-gameMode", "MP", "-role", "spectator", "-asSpectator", "true", "-gameId", "1234567890"},
+		},
+		{
+			name:            "joins as commander if launch type is launch and command",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndCommand,
+			expectedCmd:     []string{"-gameMode", "MP", "-role", "commander", "-asSpectator", "false", "-gameId", "1234567890"},
+		},
+		{
+			name:            "returns no arguments if launch type is launch only",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expectedCmd:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			u := &url.URL{Host: "1234567890"}
+			builder := OriginCmdBuilder{}
+
+			// WHEN
+			cmd, err := builder.GetArgs(mockRepository, u, game_launcher.Config{}, tt.givenLaunchType)
 
 			// THEN
 			require.NoError(t, err)
@@ -196,6 +535,13 @@ func TestRefractorV1CmdBuilder(t *testing.T) {
 			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
 			expectedCmd:     []string{"+joinServer", "1.1.1.1", "+port", "16567", "+game", "xpack"},
 		},
+		{
+			name:            "adds password argument if url contains password param",
+			givenHost:       net.JoinHostPort("1.1.1.1", "14567"),
+			givenQuery:      "password=secret",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expectedCmd:     []string{"+joinServer", "1.1.1.1", "+port", "14567", "+password", "secret"},
+		},
 		{
 			name:            "returns no arguments if launch type is any but launch and join",
 			givenHost:       net.JoinHostPort("1.1.1.1", "16567"),
@@ -213,7 +559,7 @@ func TestRefractorV1CmdBuilder(t *testing.T) {
 			builder := RefractorV1CmdBuilder{}
 
 			// WHEN
-			cmd, err := builder.GetArgs(mockRepository, u, tt.givenLaunchType)
+			cmd, err := builder.GetArgs(mockRepository, u, game_launcher.Config{}, tt.givenLaunchType)
 
 			// THEN
 			require.NoError(t, err)
@@ -222,107 +568,36 @@ func TestRefractorV1CmdBuilder(t *testing.T) {
 	}
 }
 
-func TestDeleteFileHookHandler(t *testing.T) {
+func TestCoDServerArgsBuilder(t *testing.T) {
 	type test struct {
-		name              string
-		givenConfig       game_launcher.Config
-		givenPathsBuilder func(config game_launcher.Config) ([]string, error)
-		expect            func(fr *MockFileRepository)
-		wantErrContains   string
+		name         string
+		givenInfo    *server_query.ServerInfo
+		expectedArgs []string
 	}
 
 	tests := []test{
 		{
-			name: "deletes CoD running file if present in install path",
-			givenConfig: game_launcher.Config{
-				InstallPath:    "C:\\Program Files\\Call of Duty",
-				ExecutableName: "CoDMP.exe",
-			},
-			givenPathsBuilder: CoDRunningFilePathsBuilder,
-			expect: func(fr *MockFileRepository) {
-				path := "C:\\Program Files\\Call of Duty\\__CoDMP"
-				alternate := "AppData\\Local\\VirtualStore\\Program Files\\Call of Duty\\__CoDMP"
-				fr.EXPECT().FileExists(gomock.Eq(path)).Return(true, nil)
-				fr.EXPECT().RemoveAll(gomock.Eq(path)).Return(nil)
-				fr.EXPECT().FileExists(testhelpers.StringContainsMatcher(alternate)).Return(false, nil)
-			},
-		},
-		{
-			name: "deletes CoD running file if present in virtual store",
-			givenConfig: game_launcher.Config{
-				InstallPath:    "C:\\Program Files (x86)\\Call of Duty 2",
-				ExecutableName: "CoD2MP_s.exe",
-			},
-			givenPathsBuilder: CoDRunningFilePathsBuilder,
-			expect: func(fr *MockFileRepository) {
-				primary := "C:\\Program Files (x86)\\Call of Duty 2\\__CoD2MP_s"
-				alternate := "AppData\\Local\\VirtualStore\\Program Files (x86)\\Call of Duty 2\\__CoD2MP_s"
-				fr.EXPECT().FileExists(gomock.Eq(primary)).Return(false, nil)
-				fr.EXPECT().FileExists(testhelpers.StringContainsMatcher(alternate)).Return(true, nil)
-				fr.EXPECT().RemoveAll(testhelpers.StringContainsMatcher(alternate)).Return(nil)
-			},
+			name:         "returns fs_game argument if server runs a mod",
+			givenInfo:    &server_query.ServerInfo{Mod: "mods/pml220"},
+			expectedArgs: []string{"+set", "fs_game", "mods/pml220"},
 		},
 		{
-			name: "does nothing if running file does not exist",
-			givenConfig: game_launcher.Config{
-				InstallPath:    "C:\\Program Files\\Publisher\\Game",
-				ExecutableName: "Game.exe",
-			},
-			givenPathsBuilder: func(config game_launcher.Config) ([]string, error) {
-				return []string{filepath.Join(config.InstallPath, "Game.running")}, nil
-			},
-			expect: func(fr *MockFileRepository) {
-				fr.EXPECT().FileExists(gomock.Eq("C:\\Program Files\\Publisher\\Game\\Game.running")).Return(false, nil)
-			},
-		},
-		{
-			name: "errors if paths builder fails",
-			givenConfig: game_launcher.Config{
-				InstallPath:    "C:\\Program Files\\Publisher\\Game",
-				ExecutableName: "Game.exe",
-			},
-			givenPathsBuilder: func(config game_launcher.Config) ([]string, error) {
-				return nil, fmt.Errorf("some-paths-builder-error")
-			},
-			expect:          func(fr *MockFileRepository) {},
-			wantErrContains: "some-paths-builder-error",
-		},
-		{
-			name: "errors if file exists check fails",
-			givenConfig: game_launcher.Config{
-				InstallPath:    "C:\\Program Files\\Publisher\\Game",
-				ExecutableName: "Game.exe",
-			},
-			givenPathsBuilder: func(config game_launcher.Config) ([]string, error) {
-				return []string{filepath.Join(config.InstallPath, "Game.running")}, nil
-			},
-			expect: func(fr *MockFileRepository) {
-				fr.EXPECT().FileExists(gomock.Eq("C:\\Program Files\\Publisher\\Game\\Game.running")).Return(false, fmt.Errorf("some-io-error"))
-			},
-			wantErrContains: "some-io-error",
+			name:         "returns no arguments if server does not run a mod",
+			givenInfo:    &server_query.ServerInfo{},
+			expectedArgs: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// GIVEN
-			ctrl := gomock.NewController(t)
-			mockRepository := NewMockFileRepository(ctrl)
-			u := &url.URL{Host: net.JoinHostPort("1.1.1.1", "28960")}
-			handler := MakeDeleteFileHookHandler(tt.givenPathsBuilder)
-			args := map[string]string{}
-
-			// EXPECT
-			tt.expect(mockRepository)
+			builder := CoDServerArgsBuilder{}
 
 			// WHEN
-			err := handler.Run(mockRepository, u, tt.givenConfig, game_launcher.LaunchTypeLaunchAndJoin, args)
+			args := builder.GetServerArgs(tt.givenInfo)
 
-			if tt.wantErrContains != "" {
-				assert.ErrorContains(t, err, tt.wantErrContains)
-			} else {
-				require.NoError(t, err)
-			}
+			// THEN
+			assert.Equal(t, tt.expectedArgs, args)
 		})
 	}
 }
@@ -3,3 +3,4 @@
 package internal
 
 //go:generate mockgen -destination=mock_test.go -package=$GOPACKAGE -write_package_comment=false "github.com/cetteup/joinme.click-launcher/pkg/game_launcher" FileRepository
+//go:generate mockgen -source=process.go -destination=process_mock_test.go -package=$GOPACKAGE -write_package_comment=false
@@ -8,6 +8,7 @@ package internal
 
 import (
 	fs "io/fs"
+	os "os"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -81,6 +82,35 @@ func (mr *MockFileRepositoryMockRecorder) Glob(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Glob", reflect.TypeOf((*MockFileRepository)(nil).Glob), arg0)
 }
 
+// MkdirAll mocks base method.
+func (m *MockFileRepository) MkdirAll(arg0 string, arg1 fs.FileMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MkdirAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MkdirAll indicates an expected call of MkdirAll.
+func (mr *MockFileRepositoryMockRecorder) MkdirAll(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MkdirAll", reflect.TypeOf((*MockFileRepository)(nil).MkdirAll), arg0, arg1)
+}
+
+// OpenFile mocks base method.
+func (m *MockFileRepository) OpenFile(arg0 string, arg1 int, arg2 fs.FileMode) (*os.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*os.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockFileRepositoryMockRecorder) OpenFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockFileRepository)(nil).OpenFile), arg0, arg1, arg2)
+}
+
 // ReadDir mocks base method.
 func (m *MockFileRepository) ReadDir(arg0 string) ([]fs.DirEntry, error) {
 	m.ctrl.T.Helper()
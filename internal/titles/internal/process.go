@@ -0,0 +1,217 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/process_manager"
+)
+
+const (
+	HookArgKillMode    = "mode"
+	HookArgKillTimeout = "timeout"
+
+	// KillModeForce Kills processes right away
+	KillModeForce = "force"
+	// KillModeGraceful Asks processes to close first (WM_CLOSE on Windows, SIGTERM on Linux), only killing them
+	// if they did not exit within the timeout (allowing games to write any settings/profile changes on exit)
+	KillModeGraceful = "graceful"
+
+	defaultKillTimeout         = 10 * time.Second
+	processExitCheckIterations = 5
+	processExitCheckInterval   = 1 * time.Second
+)
+
+type ProcessManager interface {
+	Processes() ([]process_manager.Process, error)
+	IsRunning(pid int) (bool, error)
+	Terminate(pid int) error
+	Kill(pid int) error
+}
+
+type Clock interface {
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (c systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// MakeKillProcessHookHandler Returns a hook handler that kills any running game processes plus any additional targets
+func MakeKillProcessHookHandler(targetLaunchExecutable bool, additionalTargets ...string) KillProcessHookHandler {
+	return KillProcessHookHandler{
+		processManager:         process_manager.New(),
+		clock:                  systemClock{},
+		targetLaunchExecutable: targetLaunchExecutable,
+		additionalTargets:      additionalTargets,
+	}
+}
+
+type KillProcessHookHandler struct {
+	processManager         ProcessManager
+	clock                  Clock
+	targetLaunchExecutable bool
+	additionalTargets      []string
+}
+
+// WithProcessManager Returns a copy of the handler using the given process manager and clock
+// (e.g. a different process backend or a fake clock for testing)
+func (h KillProcessHookHandler) WithProcessManager(processManager ProcessManager, clock Clock) KillProcessHookHandler {
+	h.processManager = processManager
+	h.clock = clock
+	return h
+}
+
+func (h KillProcessHookHandler) Run(_ game_launcher.FileRepository, _ *url.URL, config game_launcher.Config, _ game_launcher.LaunchType, args map[string]string) error {
+	mode, timeout, err := h.parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	targets := h.additionalTargets
+	if h.targetLaunchExecutable {
+		targets = append(targets, config.ExecutableName)
+	}
+
+	processes, err := h.processManager.Processes()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve process list: %s", err)
+	}
+
+	running := map[int]string{}
+	for _, process := range processes {
+		if isTargetProcess(targets, process.Executable) {
+			running[process.PID] = process.Executable
+		}
+	}
+
+	if mode == KillModeGraceful && len(running) > 0 {
+		// Only wait for processes which were actually asked to exit (e.g. processes without a window cannot be)
+		asked := map[int]string{}
+		askedPIDs := make([]int, 0, len(running))
+		for pid, executable := range running {
+			log.Info().
+				Int("pid", pid).
+				Str("executable", executable).
+				Msg("Asking existing game process to exit")
+			if err = h.processManager.Terminate(pid); err != nil {
+				// Process will be killed right away, so there is no need to abort here
+				log.Warn().
+					Err(err).
+					Int("pid", pid).
+					Str("executable", executable).
+					Msg("Failed to ask existing game process to exit")
+				continue
+			}
+			asked[pid] = executable
+			askedPIDs = append(askedPIDs, pid)
+		}
+
+		// Give processes time to exit on their own, checking at least once (rounding up timeouts which are not a
+		// multiple of the check interval)
+		iterations := int((timeout + processExitCheckInterval - 1) / processExitCheckInterval)
+		if err = h.waitForProcessesToExit(asked, iterations); err != nil {
+			return err
+		}
+
+		// Exited processes were removed from the asked processes, so remove them from the running ones as well
+		for _, pid := range askedPIDs {
+			if _, ok := asked[pid]; !ok {
+				delete(running, pid)
+			}
+		}
+	}
+
+	for pid, executable := range running {
+		log.Info().
+			Int("pid", pid).
+			Str("executable", executable).
+			Msg("Killing existing game process")
+		if err = h.processManager.Kill(pid); err != nil {
+			return fmt.Errorf("failed to kill existing game process %s (%d): %s", executable, pid, err)
+		}
+	}
+
+	// Wait for killed processes to exit
+	if err = h.waitForProcessesToExit(running, processExitCheckIterations); err != nil {
+		return err
+	}
+
+	// Return error if not all processes exited yet
+	if len(running) > 0 {
+		return fmt.Errorf("timed out waiting for killed game processes to exit")
+	}
+
+	return nil
+}
+
+func (h KillProcessHookHandler) String() string {
+	return HookKillProcess
+}
+
+// parseArgs Returns the kill mode and the timeout for processes to exit gracefully
+func (h KillProcessHookHandler) parseArgs(args map[string]string) (string, time.Duration, error) {
+	mode := KillModeForce
+	if value, ok := args[HookArgKillMode]; ok {
+		if value != KillModeForce && value != KillModeGraceful {
+			return "", 0, fmt.Errorf("invalid value for argument %s of hook %s: %s", HookArgKillMode, h.String(), value)
+		}
+		mode = value
+	}
+
+	timeout := defaultKillTimeout
+	if value, ok := args[HookArgKillTimeout]; ok {
+		var err error
+		timeout, err = time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return "", 0, fmt.Errorf("invalid value for argument %s of hook %s: %s", HookArgKillTimeout, h.String(), value)
+		}
+	}
+
+	return mode, timeout, nil
+}
+
+// waitForProcessesToExit Checks whether processes exited up to the given number of times (once per check interval),
+// removing any exited processes from the map
+func (h KillProcessHookHandler) waitForProcessesToExit(processes map[int]string, iterations int) error {
+	for i := 0; len(processes) > 0 && i < iterations; i++ {
+		for pid, executable := range processes {
+			log.Debug().
+				Int("pid", pid).
+				Str("executable", executable).
+				Msg("Checking if game process exited")
+			running, err := h.processManager.IsRunning(pid)
+			if err != nil {
+				return fmt.Errorf("failed to check if game process is still running: %s", err)
+			}
+
+			// Remove process from map if it exited (was no longer found)
+			if !running {
+				log.Debug().
+					Int("pid", pid).
+					Str("executable", executable).
+					Msg("Game process is gone")
+				delete(processes, pid)
+			}
+		}
+		h.clock.Sleep(processExitCheckInterval)
+	}
+
+	return nil
+}
+
+func isTargetProcess(targets []string, executable string) bool {
+	for _, target := range targets {
+		if executable == target {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: process.go
+//
+// Generated by this command:
+//
+//	mockgen -source=process.go -destination=process_mock_test.go -package=internal -write_package_comment=false
+package internal
+
+import (
+	reflect "reflect"
+	time "time"
+
+	process_manager "github.com/cetteup/joinme.click-launcher/pkg/process_manager"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProcessManager is a mock of ProcessManager interface.
+type MockProcessManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessManagerMockRecorder
+}
+
+// MockProcessManagerMockRecorder is the mock recorder for MockProcessManager.
+type MockProcessManagerMockRecorder struct {
+	mock *MockProcessManager
+}
+
+// NewMockProcessManager creates a new mock instance.
+func NewMockProcessManager(ctrl *gomock.Controller) *MockProcessManager {
+	mock := &MockProcessManager{ctrl: ctrl}
+	mock.recorder = &MockProcessManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessManager) EXPECT() *MockProcessManagerMockRecorder {
+	return m.recorder
+}
+
+// IsRunning mocks base method.
+func (m *MockProcessManager) IsRunning(pid int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRunning", pid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRunning indicates an expected call of IsRunning.
+func (mr *MockProcessManagerMockRecorder) IsRunning(pid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRunning", reflect.TypeOf((*MockProcessManager)(nil).IsRunning), pid)
+}
+
+// Kill mocks base method.
+func (m *MockProcessManager) Kill(pid int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kill", pid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Kill indicates an expected call of Kill.
+func (mr *MockProcessManagerMockRecorder) Kill(pid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockProcessManager)(nil).Kill), pid)
+}
+
+// Processes mocks base method.
+func (m *MockProcessManager) Processes() ([]process_manager.Process, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Processes")
+	ret0, _ := ret[0].([]process_manager.Process)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Processes indicates an expected call of Processes.
+func (mr *MockProcessManagerMockRecorder) Processes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Processes", reflect.TypeOf((*MockProcessManager)(nil).Processes))
+}
+
+// Terminate mocks base method.
+func (m *MockProcessManager) Terminate(pid int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Terminate", pid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Terminate indicates an expected call of Terminate.
+func (mr *MockProcessManagerMockRecorder) Terminate(pid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Terminate", reflect.TypeOf((*MockProcessManager)(nil).Terminate), pid)
+}
+
+// MockClock is a mock of Clock interface.
+type MockClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockMockRecorder
+}
+
+// MockClockMockRecorder is the mock recorder for MockClock.
+type MockClockMockRecorder struct {
+	mock *MockClock
+}
+
+// NewMockClock creates a new mock instance.
+func NewMockClock(ctrl *gomock.Controller) *MockClock {
+	mock := &MockClock{ctrl: ctrl}
+	mock.recorder = &MockClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClock) EXPECT() *MockClockMockRecorder {
+	return m.recorder
+}
+
+// Sleep mocks base method.
+func (m *MockClock) Sleep(d time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sleep", d)
+}
+
+// Sleep indicates an expected call of Sleep.
+func (mr *MockClockMockRecorder) Sleep(d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sleep", reflect.TypeOf((*MockClock)(nil).Sleep), d)
+}
@@ -0,0 +1,230 @@
+//go:build unit
+
+package internal
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/process_manager"
+)
+
+func TestKillProcessHookHandler_Run(t *testing.T) {
+	type test struct {
+		name            string
+		givenArgs       map[string]string
+		expect          func(pm *MockProcessManager, clock *MockClock)
+		wantErrContains string
+	}
+
+	processes := []process_manager.Process{
+		{PID: 1, Executable: "game.exe"},
+		{PID: 2, Executable: "explorer.exe"},
+		{PID: 3, Executable: "server.exe"},
+	}
+
+	tests := []test{
+		{
+			name: "kills target processes and waits for them to exit",
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes, nil)
+				pm.EXPECT().Kill(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().Kill(gomock.Eq(3)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(true, nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil)
+				pm.EXPECT().IsRunning(gomock.Eq(3)).Return(false, nil)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(2)
+			},
+		},
+		{
+			name: "does nothing if no target process is running",
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return([]process_manager.Process{{PID: 2, Executable: "explorer.exe"}}, nil)
+			},
+		},
+		{
+			name: "errors if killed processes do not exit within five checks",
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Kill(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(true, nil).Times(5)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(5)
+			},
+			wantErrContains: "timed out waiting for killed game processes to exit",
+		},
+		{
+			name:      "asks target processes to exit in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "3s"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes, nil)
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().Terminate(gomock.Eq(3)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil)
+				pm.EXPECT().IsRunning(gomock.Eq(3)).Return(false, nil)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(1)
+			},
+		},
+		{
+			name:      "kills target processes which did not exit within timeout in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "3s"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(nil)
+				gomock.InOrder(
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(true, nil).Times(3),
+					pm.EXPECT().Kill(gomock.Eq(1)).Return(nil),
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil),
+				)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(4)
+			},
+		},
+		{
+			name:      "kills target processes right away if none could be asked to exit in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "3s"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes, nil)
+				// Processes not being able to receive close requests must not prevent them from being killed
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(fmt.Errorf("some-error"))
+				pm.EXPECT().Terminate(gomock.Eq(3)).Return(fmt.Errorf("some-error"))
+				pm.EXPECT().Kill(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().Kill(gomock.Eq(3)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil)
+				pm.EXPECT().IsRunning(gomock.Eq(3)).Return(false, nil)
+				// Only waits for the killed processes to exit
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(1)
+			},
+		},
+		{
+			name:      "only waits for target processes which were asked to exit in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "3s"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes, nil)
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().Terminate(gomock.Eq(3)).Return(fmt.Errorf("some-error"))
+				gomock.InOrder(
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil),
+					pm.EXPECT().Kill(gomock.Eq(3)).Return(nil),
+					pm.EXPECT().IsRunning(gomock.Eq(3)).Return(false, nil),
+				)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(2)
+			},
+		},
+		{
+			name:      "rounds up timeouts shorter than check interval in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "500ms"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(1)
+			},
+		},
+		{
+			name:      "rounds up timeouts which are not a multiple of check interval in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "1500ms"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(nil)
+				gomock.InOrder(
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(true, nil).Times(2),
+					pm.EXPECT().Kill(gomock.Eq(1)).Return(nil),
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil),
+				)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(3)
+			},
+		},
+		{
+			name:      "uses default timeout in graceful mode",
+			givenArgs: map[string]string{"mode": "graceful"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Terminate(gomock.Eq(1)).Return(nil)
+				gomock.InOrder(
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(true, nil).Times(10),
+					pm.EXPECT().Kill(gomock.Eq(1)).Return(nil),
+					pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil),
+				)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(11)
+			},
+		},
+		{
+			name:      "kills target processes right away in force mode",
+			givenArgs: map[string]string{"mode": "force", "timeout": "3s"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Kill(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, nil)
+				clock.EXPECT().Sleep(gomock.Eq(time.Second)).Times(1)
+			},
+		},
+		{
+			name:      "errors for invalid mode",
+			givenArgs: map[string]string{"mode": "gently"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+			},
+			wantErrContains: "invalid value for argument mode of hook kill-process: gently",
+		},
+		{
+			name:      "errors for invalid timeout",
+			givenArgs: map[string]string{"mode": "graceful", "timeout": "10"},
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+			},
+			wantErrContains: "invalid value for argument timeout of hook kill-process: 10",
+		},
+		{
+			name: "errors if process list cannot be retrieved",
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(nil, fmt.Errorf("some-error"))
+			},
+			wantErrContains: "failed to retrieve process list: some-error",
+		},
+		{
+			name: "errors if process cannot be killed",
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Kill(gomock.Eq(1)).Return(fmt.Errorf("some-error"))
+			},
+			wantErrContains: "failed to kill existing game process game.exe (1): some-error",
+		},
+		{
+			name: "errors if process state cannot be checked",
+			expect: func(pm *MockProcessManager, clock *MockClock) {
+				pm.EXPECT().Processes().Return(processes[:1], nil)
+				pm.EXPECT().Kill(gomock.Eq(1)).Return(nil)
+				pm.EXPECT().IsRunning(gomock.Eq(1)).Return(false, fmt.Errorf("some-error"))
+			},
+			wantErrContains: "failed to check if game process is still running: some-error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockProcessManager := NewMockProcessManager(ctrl)
+			mockClock := NewMockClock(ctrl)
+			handler := MakeKillProcessHookHandler(true, "server.exe").WithProcessManager(mockProcessManager, mockClock)
+			config := game_launcher.Config{
+				ExecutableName: "game.exe",
+			}
+
+			// EXPECT
+			tt.expect(mockProcessManager, mockClock)
+
+			// WHEN
+			err := handler.Run(nil, nil, config, game_launcher.LaunchTypeLaunchAndJoin, tt.givenArgs)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"fmt"
+	"os/user"
+	"path/filepath"
+
+	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
+)
+
+const (
+	// Wine does not virtualize writes to protected folders, so files are always written next to the executable
+	virtualStoreSupported = false
+)
+
+// GetLocalAppDataPath Returns the local app data path inside the user's Wine prefix ($WINEPREFIX, falling back to ~/.wine)
+func GetLocalAppDataPath() (string, error) {
+	prefix, err := software_finder.GetDefaultWinePrefix()
+	if err != nil {
+		return "", err
+	}
+
+	current, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current user: %s", err)
+	}
+
+	return filepath.Join(prefix, "drive_c", "users", current.Username, "AppData", "Local"), nil
+}
+
+func buildVirtualStorePath() (string, error) {
+	return "", fmt.Errorf("virtual store is not available on this platform")
+}
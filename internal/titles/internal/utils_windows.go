@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	virtualStoreDirName = "VirtualStore"
+	// Windows redirects writes to protected folders (e.g. Program Files) to the user's VirtualStore
+	virtualStoreSupported = true
+)
+
+func GetLocalAppDataPath() (string, error) {
+	return windows.KnownFolderPath(windows.FOLDERID_LocalAppData, windows.KF_FLAG_DEFAULT)
+}
+
+func buildVirtualStorePath() (string, error) {
+	appData, err := GetLocalAppDataPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appData, virtualStoreDirName), nil
+}
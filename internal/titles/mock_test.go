@@ -1,13 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/cetteup/joinme.click-launcher/pkg/game_launcher (interfaces: FileRepository)
+// Source: github.com/cetteup/joinme.click-launcher/pkg/game_launcher (interfaces: FileRepository,ProcessRunner)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mock_test.go -package=titles -write_package_comment=false github.com/cetteup/joinme.click-launcher/pkg/game_launcher FileRepository
+//	mockgen -destination=mock_test.go -package=titles -write_package_comment=false github.com/cetteup/joinme.click-launcher/pkg/game_launcher FileRepository,ProcessRunner
 package titles
 
 import (
 	fs "io/fs"
+	os "os"
+	exec "os/exec"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -81,6 +83,35 @@ func (mr *MockFileRepositoryMockRecorder) Glob(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Glob", reflect.TypeOf((*MockFileRepository)(nil).Glob), arg0)
 }
 
+// MkdirAll mocks base method.
+func (m *MockFileRepository) MkdirAll(arg0 string, arg1 fs.FileMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MkdirAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MkdirAll indicates an expected call of MkdirAll.
+func (mr *MockFileRepositoryMockRecorder) MkdirAll(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MkdirAll", reflect.TypeOf((*MockFileRepository)(nil).MkdirAll), arg0, arg1)
+}
+
+// OpenFile mocks base method.
+func (m *MockFileRepository) OpenFile(arg0 string, arg1 int, arg2 fs.FileMode) (*os.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*os.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockFileRepositoryMockRecorder) OpenFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockFileRepository)(nil).OpenFile), arg0, arg1, arg2)
+}
+
 // ReadDir mocks base method.
 func (m *MockFileRepository) ReadDir(arg0 string) ([]fs.DirEntry, error) {
 	m.ctrl.T.Helper()
@@ -138,3 +169,55 @@ func (mr *MockFileRepositoryMockRecorder) WriteFile(arg0, arg1, arg2 any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFile", reflect.TypeOf((*MockFileRepository)(nil).WriteFile), arg0, arg1, arg2)
 }
+
+// MockProcessRunner is a mock of ProcessRunner interface.
+type MockProcessRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessRunnerMockRecorder
+}
+
+// MockProcessRunnerMockRecorder is the mock recorder for MockProcessRunner.
+type MockProcessRunnerMockRecorder struct {
+	mock *MockProcessRunner
+}
+
+// NewMockProcessRunner creates a new mock instance.
+func NewMockProcessRunner(ctrl *gomock.Controller) *MockProcessRunner {
+	mock := &MockProcessRunner{ctrl: ctrl}
+	mock.recorder = &MockProcessRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessRunner) EXPECT() *MockProcessRunnerMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockProcessRunner) Start(arg0 *exec.Cmd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockProcessRunnerMockRecorder) Start(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockProcessRunner)(nil).Start), arg0)
+}
+
+// Wait mocks base method.
+func (m *MockProcessRunner) Wait(arg0 *exec.Cmd) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockProcessRunnerMockRecorder) Wait(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockProcessRunner)(nil).Wait), arg0)
+}
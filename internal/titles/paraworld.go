@@ -27,6 +27,11 @@ var Paraworld = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Sunflowers\\ParaWorld",
 			RegistryValueName: "InstallDir",
 		},
+		{
+			// Fallback for (community) installers not writing the usual registry keys
+			ForType:            software_finder.UninstallFinder,
+			DisplayNamePattern: `(?i)^ParaWorld$`,
+		},
 	},
 	Mods: []domain.GameMod{
 		domain.MakeMod(
@@ -72,7 +77,11 @@ var Paraworld = domain.GameTitle{
 
 type paraworldCmdBuilder struct{}
 
-func (b paraworldCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, launchType game_launcher.LaunchType) ([]string, error) {
+func (b paraworldCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, _ game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	if launchType == game_launcher.LaunchTypePlayDemo {
+		return nil, localinternal.ErrDemoPlaybackNotSupported
+	}
+
 	args := make([]string, 0, 4)
 	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
 		args = append(args, "-autoconnect", fmt.Sprintf("%s:%s", u.Hostname(), u.Port()))
@@ -7,15 +7,18 @@ import (
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
+const (
+	// SWAT 4 Gold includes both the base game and the expansion, so they share the same GOG game id
+	swat4GOGGameID = "1409964317"
+)
+
 var Swat4 = domain.GameTitle{
 	Name:           "SWAT 4",
 	ProtocolScheme: "swat4",
 	FinderConfigs: []software_finder.Config{
 		{
-			ForType:           software_finder.RegistryFinder,
-			RegistryKey:       software_finder.RegistryKeyLocalMachine,
-			RegistryPath:      "SOFTWARE\\WOW6432Node\\GOG.com\\Games\\1409964317",
-			RegistryValueName: "PATH",
+			ForType:   software_finder.GOGFinder,
+			GOGGameID: swat4GOGGameID,
 		},
 		{
 			ForType:           software_finder.RegistryFinder,
@@ -36,8 +39,8 @@ var Swat4 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(),
+	URLValidator: internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatUnrealURL),
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
@@ -48,10 +51,8 @@ var Swat4X = domain.GameTitle{
 	ProtocolScheme: "swat4x",
 	FinderConfigs: []software_finder.Config{
 		{
-			ForType:           software_finder.RegistryFinder,
-			RegistryKey:       software_finder.RegistryKeyLocalMachine,
-			RegistryPath:      "SOFTWARE\\WOW6432Node\\GOG.com\\Games\\1409964317",
-			RegistryValueName: "PATH",
+			ForType:   software_finder.GOGFinder,
+			GOGGameID: swat4GOGGameID,
 		},
 		{
 			ForType:           software_finder.RegistryFinder,
@@ -72,8 +73,8 @@ var Swat4X = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(),
+	URLValidator: internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatUnrealURL),
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
@@ -0,0 +1,8 @@
+//go:build unit
+
+package titles
+
+// Profile login arguments are only available on Windows
+var bf2ProfileArgs []string
+
+func expectBf2ProfileArgs(_ *MockFileRepository) {}
@@ -0,0 +1,436 @@
+//go:build unit
+
+package titles
+
+import (
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/cetteup/joinme.click-launcher/internal"
+	"github.com/cetteup/joinme.click-launcher/internal/domain"
+	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+)
+
+func TestTitles_CommandLine(t *testing.T) {
+	type test struct {
+		name            string
+		givenTitle      domain.GameTitle
+		givenURL        string
+		givenLaunchType game_launcher.LaunchType
+		expect          func(fr *MockFileRepository)
+		wantArgs        []string
+	}
+
+	bf2DefaultArgs := append([]string{"+menu", "1", "+restart", "1"}, bf2ProfileArgs...)
+	noExpect := func(fr *MockFileRepository) {}
+	tests := []test{
+		{
+			name:            "Battlefield 1942 launch and join",
+			givenTitle:      Bf1942,
+			givenURL:        "bf1942://1.1.1.1:14567",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+restart", "1", "+joinServer", "1.1.1.1", "+port", "14567"},
+		},
+		{
+			name:            "Battlefield 1942 launch only",
+			givenTitle:      Bf1942,
+			givenURL:        "bf1942://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{"+restart", "1"},
+		},
+		{
+			name:            "Battlefield Vietnam launch and join",
+			givenTitle:      BfVietnam,
+			givenURL:        "bfvietnam://1.1.1.1:15567",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+restart", "1", "+joinServer", "1.1.1.1", "+port", "15567"},
+		},
+		{
+			name:            "Battlefield Vietnam launch only",
+			givenTitle:      BfVietnam,
+			givenURL:        "bfvietnam://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{"+restart", "1"},
+		},
+		{
+			name:            "Battlefield 2 launch and join",
+			givenTitle:      Bf2,
+			givenURL:        "bf2://1.1.1.1:16567",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          expectBf2ProfileArgs,
+			wantArgs:        append(append([]string{}, bf2DefaultArgs...), "+joinServer", "1.1.1.1", "+port", "16567"),
+		},
+		{
+			name:            "Battlefield 2 launch only",
+			givenTitle:      Bf2,
+			givenURL:        "bf2://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          expectBf2ProfileArgs,
+			wantArgs:        bf2DefaultArgs,
+		},
+		{
+			name:            "Battlefield 4 launch and join",
+			givenTitle:      Bf4,
+			givenURL:        "bf4://1234567890",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"-gameMode", "MP", "-role", "soldier", "-asSpectator", "false", "-gameId", "1234567890"},
+		},
+		{
+			name:            "Battlefield 4 launch only",
+			givenTitle:      Bf4,
+			givenURL:        "bf4://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Battlefield 1 launch and join",
+			givenTitle:      Bf1,
+			givenURL:        "bf1://1234567890",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"-gameMode", "MP", "-role", "soldier", "-asSpectator", "false", "-gameId", "1234567890"},
+		},
+		{
+			name:            "Battlefield 1 launch only",
+			givenTitle:      Bf1,
+			givenURL:        "bf1://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Call of Duty launch and join",
+			givenTitle:      Cod,
+			givenURL:        "cod://1.1.1.1:28960",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+connect", "1.1.1.1:28960"},
+		},
+		{
+			name:            "Call of Duty launch only",
+			givenTitle:      Cod,
+			givenURL:        "cod://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Call of Duty: United Offensive launch and join",
+			givenTitle:      CodUO,
+			givenURL:        "coduo://1.1.1.1:28960",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+connect", "1.1.1.1:28960"},
+		},
+		{
+			name:            "Call of Duty: United Offensive launch only",
+			givenTitle:      CodUO,
+			givenURL:        "coduo://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Call of Duty 2 launch and join",
+			givenTitle:      Cod2,
+			givenURL:        "cod2://1.1.1.1:28960",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+connect", "1.1.1.1:28960"},
+		},
+		{
+			name:            "Call of Duty 2 launch only",
+			givenTitle:      Cod2,
+			givenURL:        "cod2://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Call of Duty 4 launch and join",
+			givenTitle:      Cod4,
+			givenURL:        "cod4://1.1.1.1:28960",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+connect", "1.1.1.1:28960"},
+		},
+		{
+			name:            "Call of Duty 4 launch only",
+			givenTitle:      Cod4,
+			givenURL:        "cod4://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Call of Duty: World at War launch and join",
+			givenTitle:      CodWaw,
+			givenURL:        "codwaw://1.1.1.1:28960",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+connect", "1.1.1.1:28960"},
+		},
+		{
+			name:            "Call of Duty: World at War launch only",
+			givenTitle:      CodWaw,
+			givenURL:        "codwaw://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "F.E.A.R. launch and join",
+			givenTitle:      Fear,
+			givenURL:        "fear://1.1.1.1:27888",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+join", "1.1.1.1:27888"},
+		},
+		{
+			name:            "F.E.A.R. launch only",
+			givenTitle:      Fear,
+			givenURL:        "fear://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "F.E.A.R. Combat (SEC2) launch and join",
+			givenTitle:      FearSec2,
+			givenURL:        "fearsec2://1.1.1.1:27888",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"+join", "1.1.1.1:27888"},
+		},
+		{
+			name:            "F.E.A.R. Combat (SEC2) launch only",
+			givenTitle:      FearSec2,
+			givenURL:        "fearsec2://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "ParaWorld launch and join",
+			givenTitle:      Paraworld,
+			givenURL:        "paraworld://1.1.1.1:7777",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"-autoconnect", "1.1.1.1:7777"},
+		},
+		{
+			name:            "ParaWorld launch only",
+			givenTitle:      Paraworld,
+			givenURL:        "paraworld://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "SWAT 4 launch and join",
+			givenTitle:      Swat4,
+			givenURL:        "swat4://1.1.1.1:10480",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"1.1.1.1:10480"},
+		},
+		{
+			name:            "SWAT 4 launch only",
+			givenTitle:      Swat4,
+			givenURL:        "swat4://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "SWAT 4: The Stetchkov Syndicate launch and join",
+			givenTitle:      Swat4X,
+			givenURL:        "swat4x://1.1.1.1:10480",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"1.1.1.1:10480"},
+		},
+		{
+			name:            "SWAT 4: The Stetchkov Syndicate launch only",
+			givenTitle:      Swat4X,
+			givenURL:        "swat4x://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Unreal launch and join",
+			givenTitle:      Unreal,
+			givenURL:        "unreal://1.1.1.1:7777",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"1.1.1.1:7777"},
+		},
+		{
+			name:            "Unreal launch only",
+			givenTitle:      Unreal,
+			givenURL:        "unreal://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Unreal Tournament launch and join",
+			givenTitle:      UT,
+			givenURL:        "ut://1.1.1.1:7777",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"1.1.1.1:7777"},
+		},
+		{
+			name:            "Unreal Tournament launch only",
+			givenTitle:      UT,
+			givenURL:        "ut://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Unreal Tournament 2003 launch and join",
+			givenTitle:      UT2003,
+			givenURL:        "ut2003://1.1.1.1:7777",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"1.1.1.1:7777"},
+		},
+		{
+			name:            "Unreal Tournament 2003 launch only",
+			givenTitle:      UT2003,
+			givenURL:        "ut2003://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Unreal Tournament 2004 launch and join",
+			givenTitle:      UT2004,
+			givenURL:        "ut2004://1.1.1.1:7777",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"1.1.1.1:7777"},
+		},
+		{
+			name:            "Unreal Tournament 2004 launch only",
+			givenTitle:      UT2004,
+			givenURL:        "ut2004://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+		{
+			name:            "Vietcong launch and join",
+			givenTitle:      Vietcong,
+			givenURL:        "vietcong://1.1.1.1:5425",
+			givenLaunchType: game_launcher.LaunchTypeLaunchAndJoin,
+			expect:          noExpect,
+			wantArgs:        []string{"-ip", "1.1.1.1", "-port", "5425"},
+		},
+		{
+			name:            "Vietcong launch only",
+			givenTitle:      Vietcong,
+			givenURL:        "vietcong://act/launch",
+			givenLaunchType: game_launcher.LaunchTypeLaunchOnly,
+			expect:          noExpect,
+			wantArgs:        []string{},
+		},
+	}
+
+	installPath := filepath.FromSlash("/games/some-game")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			mockRunner := NewMockProcessRunner(ctrl)
+			launcher := game_launcher.New(mockRepository, mockRunner, false)
+			u, err := url.Parse(tt.givenURL)
+			require.NoError(t, err)
+			config := tt.givenTitle.LauncherConfig
+			config.InstallPath = installPath
+
+			executablePath := filepath.FromSlash(strings.ReplaceAll(config.ExecutablePath, "\\", "/"))
+			wantPath := filepath.Join(installPath, executablePath, config.ExecutableName)
+			wantDir := installPath
+			if config.StartIn == game_launcher.LaunchDirBinaryDir {
+				wantDir = filepath.Dir(wantPath)
+			}
+
+			// EXPECT
+			tt.expect(mockRepository)
+			var cmd *exec.Cmd
+			mockRunner.EXPECT().Start(gomock.Any()).DoAndReturn(func(c *exec.Cmd) error {
+				cmd = c
+				return nil
+			})
+
+			// WHEN
+			// Pass no hook handlers, so hooks (such as killing running game processes) are skipped
+			_, err = launcher.StartGame(u, config, tt.givenLaunchType, tt.givenTitle.CmdBuilder)
+
+			// THEN
+			require.NoError(t, err)
+			require.NotNil(t, cmd)
+			assert.Equal(t, wantPath, cmd.Path)
+			assert.Equal(t, append([]string{wantPath}, tt.wantArgs...), cmd.Args)
+			assert.Equal(t, wantDir, cmd.Dir)
+		})
+	}
+}
+
+func TestTitles_CrashPolicyWithoutMaxRetries(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	mockRepository := NewMockFileRepository(ctrl)
+	mockRunner := NewMockProcessRunner(ctrl)
+	launcher := game_launcher.New(mockRepository, mockRunner, false)
+	u, err := url.Parse("ut2004://act/launch")
+	require.NoError(t, err)
+	title := UT2004
+	// Only configure the min runtime (keeping it short, so the test does not need to wait long for a non-crash session)
+	title.AddCustomConfig(internal.CustomLauncherConfig{
+		CrashPolicy: &internal.CustomCrashPolicyConfig{
+			MinRuntime: time.Millisecond,
+		},
+	})
+	config := title.LauncherConfig
+	config.InstallPath = filepath.FromSlash("/games/some-game")
+
+	// EXPECT
+	gomock.InOrder(
+		mockRunner.EXPECT().Start(gomock.Any()).Return(nil),
+		mockRunner.EXPECT().Wait(gomock.Any()).Return(1, nil),
+		// Game is relaunched after crashing
+		mockRunner.EXPECT().Start(gomock.Any()).Return(nil),
+		mockRunner.EXPECT().Wait(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 0, nil
+		}),
+	)
+
+	// WHEN
+	// Pass no hook handlers, so hooks (such as killing running game processes) are skipped
+	record, err := launcher.StartGame(u, config, game_launcher.LaunchTypeLaunchOnly, title.CmdBuilder)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, 1, record.Relaunches)
+}
@@ -0,0 +1,14 @@
+//go:build unit
+
+package titles
+
+import (
+	"github.com/cetteup/joinme.click-launcher/internal/testhelpers"
+)
+
+var bf2ProfileArgs = []string{"+playerName", "some-player"}
+
+func expectBf2ProfileArgs(fr *MockFileRepository) {
+	fr.EXPECT().ReadFile(testhelpers.StringContainsMatcher("Battlefield 2\\Profiles\\Global.con")).Return([]byte("GlobalSettings.setDefaultUser \"0001\""), nil)
+	fr.EXPECT().ReadFile(testhelpers.StringContainsMatcher("Battlefield 2\\Profiles\\0001\\Profile.con")).Return([]byte("LocalProfile.setNick \"some-player\""), nil)
+}
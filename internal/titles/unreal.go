@@ -4,6 +4,7 @@ import (
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	"github.com/cetteup/joinme.click-launcher/internal/titles/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
@@ -35,6 +36,10 @@ var Unreal = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Unreal Technology\\Installed Apps\\Unreal", // Path is only set by patch, not the original install itself
 			RegistryValueName: "Folder",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "13250",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "Unreal.exe",
@@ -47,8 +52,13 @@ var Unreal = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(),
+	QueryConfig: &server_query.Config{
+		Protocol:   server_query.ProtocolGameSpy1,
+		PortOffset: 1,
+	},
+	URLValidator: internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatUnrealURL),
+	LogFiles:     []string{"System/Unreal.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
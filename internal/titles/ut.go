@@ -4,6 +4,7 @@ import (
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	"github.com/cetteup/joinme.click-launcher/internal/titles/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
@@ -29,6 +30,10 @@ var UT = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Unreal Technology\\Installed Apps\\UnrealTournament", // Disk version uses WOW6432Node
 			RegistryValueName: "Folder",
 		},
+		{
+			ForType:    software_finder.SteamFinder,
+			SteamAppID: "13240",
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "UnrealTournament.exe",
@@ -41,8 +46,13 @@ var UT = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(),
+	QueryConfig: &server_query.Config{
+		Protocol:   server_query.ProtocolGameSpy1,
+		PortOffset: 1,
+	},
+	URLValidator: internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatUnrealURL),
+	LogFiles:     []string{"System/UnrealTournament.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
@@ -4,6 +4,7 @@ import (
 	"github.com/cetteup/joinme.click-launcher/internal/domain"
 	"github.com/cetteup/joinme.click-launcher/internal/titles/internal"
 	"github.com/cetteup/joinme.click-launcher/pkg/game_launcher"
+	"github.com/cetteup/joinme.click-launcher/pkg/server_query"
 	"github.com/cetteup/joinme.click-launcher/pkg/software_finder"
 )
 
@@ -29,8 +30,14 @@ var UT2003 = domain.GameTitle{
 			},
 		},
 	},
-	URLValidator: internal.IPPortURLValidator{},
-	CmdBuilder:   internal.MakeSimpleCmdBuilder(),
+	QueryConfig: &server_query.Config{
+		Protocol: server_query.ProtocolGameSpy1,
+		// UT2003 answers GameSpy v1 queries on the game port + 10
+		PortOffset: 10,
+	},
+	URLValidator: internal.IPPortURLValidator{AllowPassword: true},
+	CmdBuilder:   internal.MakeSimpleCmdBuilder(internal.PasswordFormatUnrealURL),
+	LogFiles:     []string{"System/UT2003.log"},
 	HookHandlers: []game_launcher.HookHandler{
 		internal.MakeKillProcessHookHandler(true),
 	},
@@ -19,6 +19,11 @@ var Vietcong = domain.GameTitle{
 			RegistryPath:      "SOFTWARE\\WOW6432Node\\Pterodon\\Vietcong",
 			RegistryValueName: "InstallDir",
 		},
+		{
+			// Fallback for (community) installers not writing the usual registry keys
+			ForType:            software_finder.UninstallFinder,
+			DisplayNamePattern: `(?i)^Vietcong$`,
+		},
 	},
 	LauncherConfig: game_launcher.Config{
 		ExecutableName: "vietcong.exe",
@@ -39,7 +44,11 @@ var Vietcong = domain.GameTitle{
 
 type vietcongCmdBuilder struct{}
 
-func (b vietcongCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, launchType game_launcher.LaunchType) ([]string, error) {
+func (b vietcongCmdBuilder) GetArgs(_ game_launcher.FileRepository, u *url.URL, _ game_launcher.Config, launchType game_launcher.LaunchType) ([]string, error) {
+	if launchType == game_launcher.LaunchTypePlayDemo {
+		return nil, internal.ErrDemoPlaybackNotSupported
+	}
+
 	args := make([]string, 0, 4)
 	if launchType == game_launcher.LaunchTypeLaunchAndJoin {
 		args = append(args, "-ip", u.Hostname(), "-port", u.Port())
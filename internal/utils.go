@@ -3,15 +3,27 @@ package internal
 import (
 	"net"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
 const (
-	urlQueryKeyMod = "mod"
-	portMin        = 1
-	portMax        = 65535
+	urlQueryKeyMod      = "mod"
+	urlQueryKeyPassword = "password"
+	redactedValue       = "xxxxx"
+	portMin             = 1
+	portMax             = 65535
+
+	hostnameMaxLength = 253
 )
 
+// Hostname labels as per RFC 1123 (letters, digits and hyphens, not starting or ending with a hyphen)
+var hostnameLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// Password query param value, matched on the raw string so even URLs which cannot be parsed get redacted
+var urlPasswordRegex = regexp.MustCompile(`([?&]` + urlQueryKeyPassword + `=)[^&#]*`)
+
 func QueryHasMod(query url.Values) bool {
 	return query != nil && query.Has(urlQueryKeyMod)
 }
@@ -20,6 +32,19 @@ func GetModFromQuery(query url.Values) string {
 	return query.Get(urlQueryKeyMod)
 }
 
+func QueryHasPassword(query url.Values) bool {
+	return query != nil && query.Get(urlQueryKeyPassword) != ""
+}
+
+func GetPasswordFromQuery(query url.Values) string {
+	return query.Get(urlQueryKeyPassword)
+}
+
+// RedactURL Masks the server password (if any) in the given URL, so it can safely be logged
+func RedactURL(rawURL string) string {
+	return urlPasswordRegex.ReplaceAllString(rawURL, "${1}"+redactedValue)
+}
+
 func IsValidIPv4(input string) bool {
 	ip := net.ParseIP(input)
 	if ip == nil {
@@ -28,6 +53,34 @@ func IsValidIPv4(input string) bool {
 	return ip.To4() != nil && (ip.IsGlobalUnicast() || ip.IsLoopback())
 }
 
+// IsValidIPv6 Checks whether the input is a valid (non-bracketed) global unicast or loopback IPv6 address
+func IsValidIPv6(input string) bool {
+	ip := net.ParseIP(input)
+	if ip == nil {
+		return false
+	}
+	return ip.To4() == nil && (ip.IsGlobalUnicast() || ip.IsLoopback())
+}
+
+// IsValidHostname Checks whether the input is a valid DNS hostname (rather than an IP address)
+func IsValidHostname(input string) bool {
+	hostname := strings.TrimSuffix(input, ".")
+	if hostname == "" || len(hostname) > hostnameMaxLength {
+		return false
+	}
+
+	labels := strings.Split(hostname, ".")
+	for _, label := range labels {
+		if !hostnameLabelRegex.MatchString(label) {
+			return false
+		}
+	}
+
+	// Top-level domains are never all-numeric, which also rules out (invalid) IPv4 addresses such as 1.1.1.256
+	_, err := strconv.Atoi(labels[len(labels)-1])
+	return err != nil
+}
+
 func IsValidPort(input string) bool {
 	portAsInt, err := strconv.ParseInt(input, 10, 32)
 	if err != nil {
@@ -95,6 +95,88 @@ func TestGetModFromQuery(t *testing.T) {
 	}
 }
 
+func TestQueryHasPassword(t *testing.T) {
+	type test struct {
+		name            string
+		givenQuery      url.Values
+		wantHasPassword bool
+	}
+
+	tests := []test{
+		{
+			name: "true if query contains password key",
+			givenQuery: map[string][]string{
+				urlQueryKeyPassword: {"secret"},
+			},
+			wantHasPassword: true,
+		},
+		{
+			name: "false if query contains empty password",
+			givenQuery: map[string][]string{
+				urlQueryKeyPassword: {""},
+			},
+			wantHasPassword: false,
+		},
+		{
+			name: "false if query does not contain password key",
+			givenQuery: map[string][]string{
+				"some-other-query-param": {"some-value"},
+			},
+			wantHasPassword: false,
+		},
+		{
+			name:            "false if query is nil",
+			givenQuery:      nil,
+			wantHasPassword: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasPassword := QueryHasPassword(tt.givenQuery)
+			assert.Equal(t, tt.wantHasPassword, hasPassword)
+		})
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	type test struct {
+		name       string
+		givenURL   string
+		wantResult string
+	}
+
+	tests := []test{
+		{
+			name:       "masks password as only query param",
+			givenURL:   "cod4://1.2.3.4:28960?password=secret",
+			wantResult: "cod4://1.2.3.4:28960?password=xxxxx",
+		},
+		{
+			name:       "masks password between other query params",
+			givenURL:   "bf2://1.2.3.4:16567?mod=xpack&password=secret&foo=bar",
+			wantResult: "bf2://1.2.3.4:16567?mod=xpack&password=xxxxx&foo=bar",
+		},
+		{
+			name:       "masks password in URL which cannot be parsed",
+			givenURL:   "cod4://1.2.3.4:port?password=secret",
+			wantResult: "cod4://1.2.3.4:port?password=xxxxx",
+		},
+		{
+			name:       "returns URL without password as is",
+			givenURL:   "bf2://1.2.3.4:16567?mod=xpack",
+			wantResult: "bf2://1.2.3.4:16567?mod=xpack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RedactURL(tt.givenURL)
+			assert.Equal(t, tt.wantResult, result)
+		})
+	}
+}
+
 func TestIsValidIPv4(t *testing.T) {
 	type test struct {
 		name        string
@@ -153,6 +235,122 @@ func TestIsValidIPv4(t *testing.T) {
 	}
 }
 
+func TestIsValidIPv6(t *testing.T) {
+	type test struct {
+		name        string
+		givenInput  string
+		wantIsValid bool
+	}
+
+	tests := []test{
+		{
+			name:        "true for public IPv6",
+			givenInput:  "2606:4700:4700::1111",
+			wantIsValid: true,
+		},
+		{
+			name:        "true for private IPv6",
+			givenInput:  "fd77:5a47:30c1:37e4::aaaa",
+			wantIsValid: true,
+		},
+		{
+			name:        "true for loopback IPv6",
+			givenInput:  "::1",
+			wantIsValid: true,
+		},
+		{
+			name:        "false for link-local IPv6",
+			givenInput:  "fe80::1",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for IPv4",
+			givenInput:  "1.1.1.1",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for IPv4-mapped IPv6",
+			givenInput:  "::ffff:1.1.1.1",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for bracketed IPv6",
+			givenInput:  "[::1]",
+			wantIsValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isValid := IsValidIPv6(tt.givenInput)
+			assert.Equal(t, tt.wantIsValid, isValid)
+		})
+	}
+}
+
+func TestIsValidHostname(t *testing.T) {
+	type test struct {
+		name        string
+		givenInput  string
+		wantIsValid bool
+	}
+
+	tests := []test{
+		{
+			name:        "true for valid hostname",
+			givenInput:  "play.example.org",
+			wantIsValid: true,
+		},
+		{
+			name:        "true for fully qualified hostname",
+			givenInput:  "bf2.example-clan.com.",
+			wantIsValid: true,
+		},
+		{
+			name:        "true for single label hostname",
+			givenInput:  "gameserver",
+			wantIsValid: true,
+		},
+		{
+			name:        "false for IPv4",
+			givenInput:  "1.1.1.1",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for IPv6",
+			givenInput:  "2606:4700:4700::1111",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for label starting with hyphen",
+			givenInput:  "-play.example.org",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for empty label",
+			givenInput:  "play..example.org",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for invalid characters",
+			givenInput:  "play_server.example.org",
+			wantIsValid: false,
+		},
+		{
+			name:        "false for empty input",
+			givenInput:  "",
+			wantIsValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isValid := IsValidHostname(tt.givenInput)
+			assert.Equal(t, tt.wantIsValid, isValid)
+		})
+	}
+}
+
 func TestIsValidPort(t *testing.T) {
 	type test struct {
 		name        string
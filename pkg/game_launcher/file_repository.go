@@ -0,0 +1,26 @@
+package game_launcher
+
+import (
+	"os"
+
+	filerepo "github.com/cetteup/filerepo/pkg"
+)
+
+// OSFileRepository Extends filerepo's file repository with the functions required to write launch reports
+type OSFileRepository struct {
+	*filerepo.FileRepository
+}
+
+func NewOSFileRepository(repository *filerepo.FileRepository) *OSFileRepository {
+	return &OSFileRepository{
+		FileRepository: repository,
+	}
+}
+
+func (r *OSFileRepository) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (r *OSFileRepository) OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(path, flag, perm)
+}
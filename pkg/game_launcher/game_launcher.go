@@ -1,10 +1,15 @@
 package game_launcher
 
 import (
+	"fmt"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -17,17 +22,38 @@ const (
 	LaunchDirInstallDir LaunchDir = "install-dir"
 	LaunchDirBinaryDir  LaunchDir = "binary-dir"
 
-	LaunchTypeLaunchAndJoin LaunchType = "launch-and-join"
-	LaunchTypeLaunchOnly    LaunchType = "launch-only"
+	LaunchTypeLaunchAndJoin     LaunchType = "launch-and-join"
+	LaunchTypeLaunchOnly        LaunchType = "launch-only"
+	LaunchTypeLaunchAndSpectate LaunchType = "launch-and-spectate"
+	LaunchTypeLaunchAndCommand  LaunchType = "launch-and-command"
+	LaunchTypePlayDemo          LaunchType = "play-demo"
 
-	HookWhenAlways     HookWhen = "always"
+	HookWhenAlways     HookWhen = "always" // pre-launch and post-launch
 	HookWhenPreLaunch  HookWhen = "pre-launch"
 	HookWhenPostLaunch HookWhen = "post-launch"
+	HookWhenPostExit   HookWhen = "post-exit"  // only if the launcher waits for the game to exit
+	HookWhenPostCrash  HookWhen = "post-crash" // only if the game crashed and is about to be relaunched
 	HookWhenNever      HookWhen = "never"
 
 	handlerLogKey = "handler"
+
+	// DefaultCrashMinRuntime Sessions ending sooner than this are considered crashes unless configured otherwise
+	DefaultCrashMinRuntime = 5 * time.Second
+	// DefaultCrashMaxRetries How often crashed games are relaunched unless configured otherwise
+	DefaultCrashMaxRetries = 1
+
+	reportDirPerm       = 0755
+	reportFilePerm      = 0644
+	reportOutputLogName = "output.log"
+
+	// Server passwords are passed via URL query and must never end up in any log output
+	urlQueryKeyPassword = "password"
+	redactedValue       = "xxxxx"
 )
 
+// unrealURLPasswordRegex Matches the password option of an Unreal URL (<host>:<port>?password=<password>)
+var unrealURLPasswordRegex = regexp.MustCompile(`(\?` + urlQueryKeyPassword + `=)[^?]*`)
+
 type FileRepository interface {
 	FileExists(path string) (bool, error)
 	DirExists(path string) (bool, error)
@@ -36,15 +62,30 @@ type FileRepository interface {
 	ReadDir(path string) ([]os.DirEntry, error)
 	Glob(pattern string) ([]string, error)
 	RemoveAll(path string) error
+	MkdirAll(path string, perm os.FileMode) error
+	OpenFile(path string, flag int, perm os.FileMode) (*os.File, error)
+}
+
+type ProcessRunner interface {
+	Start(cmd *exec.Cmd) error
+	// Wait Waits for a started process to exit, returning its exit code
+	Wait(cmd *exec.Cmd) (int, error)
 }
 
 type GameLauncher struct {
 	repository FileRepository
+	runner     ProcessRunner
+	// Whether to only print the command and hooks instead of actually launching the game
+	dryRun bool
+	now    func() time.Time
 }
 
-func New(repository FileRepository) *GameLauncher {
+func New(repository FileRepository, runner ProcessRunner, dryRun bool) *GameLauncher {
 	return &GameLauncher{
 		repository: repository,
+		runner:     runner,
+		dryRun:     dryRun,
+		now:        time.Now,
 	}
 }
 
@@ -56,7 +97,52 @@ type Config struct {
 	// Relative path from install path to folder containing the executable
 	ExecutablePath string
 	InstallPath    string
-	HookConfigs    []HookConfig
+	// Command chain to run the executable with (e.g. ["gamemoderun", "wine"])
+	Wrapper []string
+	// Additional environment variables to launch the game with (e.g. WINEPREFIX)
+	Env         map[string]string
+	HookConfigs []HookConfig
+	// Whether to wait for the game to exit (supervised mode), allowing to run post-exit hooks
+	WaitForExit bool
+	// How to detect and recover from crashes in supervised mode (crashes are not detected if nil)
+	CrashPolicy *CrashPolicy
+	// Dir to write the game's output to and, in supervised mode, to collect its log files in after it exited
+	// (no report is created if empty)
+	ReportDir string
+	// Glob patterns of log files (or dirs containing log files) relative to the install path, e.g. "System/UT2004.log"
+	LogFiles []string
+}
+
+// GameExit Outcome of a game session in supervised mode
+type GameExit struct {
+	ExitCode int
+	Runtime  time.Duration
+}
+
+type CrashPolicy struct {
+	// Sessions ending sooner than this are considered crashes, even if the game exited normally
+	MinRuntime time.Duration
+	// How often to relaunch the game after it crashed
+	MaxRetries int
+}
+
+// LaunchRecord What the launcher did while launching the game (e.g. to keep a launch history)
+type LaunchRecord struct {
+	// Final command line (with any server password redacted)
+	Args  []string
+	Hooks []HookRecord
+	// How often the game was relaunched after crashing
+	Relaunches int
+	// Outcome of the last game session (nil unless the launcher waited for the game to exit)
+	Exit   *GameExit
+	DryRun bool
+}
+
+type HookRecord struct {
+	Handler  string
+	When     HookWhen
+	Duration time.Duration
+	Err      error
 }
 
 type HookConfig struct {
@@ -72,9 +158,9 @@ type URLValidator interface {
 }
 
 type CommandBuilder interface {
-	// GetArgs Construct slice of launch arguments for a game. Receives a file repository to be able to access any
-	// config file it may need to construct the arguments.
-	GetArgs(fr FileRepository, u *url.URL, launchType LaunchType) ([]string, error)
+	// GetArgs Construct slice of launch arguments for a game. Receives a file repository and the launcher config
+	// (containing the install path) to be able to access any (config) file it may need to construct the arguments.
+	GetArgs(fr FileRepository, u *url.URL, config Config, launchType LaunchType) ([]string, error)
 }
 
 type HookHandler interface {
@@ -82,57 +168,300 @@ type HookHandler interface {
 	String() string
 }
 
-func (l *GameLauncher) StartGame(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder, hookHandlers ...HookHandler) error {
+func (l *GameLauncher) StartGame(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder, hookHandlers ...HookHandler) (LaunchRecord, error) {
 	// Convert handlers to map to make access faster/easier
 	hookHandlerMap := toHookHandlerMap(hookHandlers)
 
+	record := &LaunchRecord{DryRun: l.dryRun}
+	if l.dryRun {
+		return *record, l.planGame(u, config, launchType, cmdBuilder, hookHandlerMap, record)
+	}
+
+	for ; ; record.Relaunches++ {
+		exit, err := l.runGame(u, config, launchType, cmdBuilder, hookHandlerMap, record)
+		if err != nil {
+			return *record, err
+		}
+
+		// Game was either not supervised or did not crash
+		if exit == nil || !isCrash(*exit, config.CrashPolicy) {
+			return *record, nil
+		}
+
+		if record.Relaunches >= config.CrashPolicy.MaxRetries {
+			return *record, fmt.Errorf("game crashed (exit code %d after %s), giving up after %d relaunch attempt(s)", exit.ExitCode, exit.Runtime, record.Relaunches)
+		}
+
+		log.Warn().Int("exitCode", exit.ExitCode).Dur("runtime", exit.Runtime).Int("attempt", record.Relaunches+1).Msg("Game crashed, relaunching")
+
+		// Run post-crash hooks to recover before relaunching
+		if err = l.runHooks(u, config, launchType, hookHandlerMap, HookWhenPostCrash, record); err != nil {
+			return *record, err
+		}
+	}
+}
+
+// runGame Runs pre-launch hooks, starts the game and runs post-launch hooks. In supervised mode, it then waits for
+// the game to exit and runs post-exit hooks.
+func (l *GameLauncher) runGame(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder, hookHandlerMap map[string]HookHandler, record *LaunchRecord) (*GameExit, error) {
 	// Run pre-launch hooks
-	if err := l.runHooks(u, config, launchType, hookHandlerMap, HookWhenPreLaunch); err != nil {
-		return err
+	if err := l.runHooks(u, config, launchType, hookHandlerMap, HookWhenPreLaunch, record); err != nil {
+		return nil, err
 	}
 
 	// Start the game
-	if err := l.startGame(u, config, launchType, cmdBuilder); err != nil {
-		return err
+	cmd, err := l.startGame(u, config, launchType, cmdBuilder)
+	if err != nil {
+		return nil, err
 	}
+	startedAt := l.now()
+	record.Args = redactArgs(cmd.Args, u)
 
 	// Run post-launch hooks
-	return l.runHooks(u, config, launchType, hookHandlerMap, HookWhenPostLaunch)
+	if err = l.runHooks(u, config, launchType, hookHandlerMap, HookWhenPostLaunch, record); err != nil {
+		return nil, err
+	}
+
+	if !config.WaitForExit {
+		return nil, nil
+	}
+
+	exit, err := l.waitForGame(cmd, startedAt)
+	if err != nil {
+		return nil, err
+	}
+	record.Exit = &exit
+
+	if config.ReportDir != "" {
+		l.collectLogFiles(config)
+	}
+
+	// Run post-exit hooks
+	if err = l.runHooks(u, config, launchType, hookHandlerMap, HookWhenPostExit, record); err != nil {
+		return nil, err
+	}
+
+	return &exit, nil
 }
 
-func (l *GameLauncher) runHooks(u *url.URL, config Config, launchType LaunchType, handlers map[string]HookHandler, when HookWhen) error {
+// isCrash Checks whether the game crashed according to the policy (never, if there is no policy)
+func isCrash(exit GameExit, policy *CrashPolicy) bool {
+	if policy == nil {
+		return false
+	}
+	return exit.ExitCode != 0 || exit.Runtime < policy.MinRuntime
+}
+
+func (l *GameLauncher) runHooks(u *url.URL, config Config, launchType LaunchType, handlers map[string]HookHandler, when HookWhen, record *LaunchRecord) error {
+	for _, hc := range selectHooks(config, handlers, when) {
+		log.Debug().Str(handlerLogKey, hc.Handler).Interface("args", hc.Args).Msg("Running hook handler")
+
+		startedAt := l.now()
+		err := handlers[hc.Handler].Run(l.repository, u, config, launchType, hc.Args)
+		record.Hooks = append(record.Hooks, HookRecord{
+			Handler:  hc.Handler,
+			When:     when,
+			Duration: l.now().Sub(startedAt),
+			Err:      err,
+		})
+		if err != nil {
+			log.Error().Err(err).Str(handlerLogKey, hc.Handler).Msg("Hook handler execution failed")
+			if hc.ExitOnError {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// selectHooks Returns the configs of all known hooks configured to run at the given time
+func selectHooks(config Config, handlers map[string]HookHandler, when HookWhen) []HookConfig {
+	selected := make([]HookConfig, 0, len(config.HookConfigs))
 	for _, hc := range config.HookConfigs {
-		if hc.When != when && hc.When != HookWhenAlways {
+		if hc.When != when && !(hc.When == HookWhenAlways && (when == HookWhenPreLaunch || when == HookWhenPostLaunch)) {
 			log.Debug().Str(handlerLogKey, hc.Handler).Str("when", string(when)).Msg("Skipping hook handler not configured to run now")
 			continue
 		}
 
-		handler, ok := handlers[hc.Handler]
-		if !ok {
+		if _, ok := handlers[hc.Handler]; !ok {
 			log.Warn().Str(handlerLogKey, hc.Handler).Msg("Skipping unknown hook handler")
 			continue
 		}
 
-		log.Debug().Str(handlerLogKey, hc.Handler).Interface("args", hc.Args).Msg("Running hook handler")
+		selected = append(selected, hc)
+	}
+	return selected
+}
+
+// planGame Prints the command the game would be started with and the hooks that would run, without running either
+func (l *GameLauncher) planGame(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder, handlers map[string]HookHandler, record *LaunchRecord) error {
+	args, err := l.getArgs(u, config, launchType, cmdBuilder)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := buildCmd(config, args)
+	if err != nil {
+		return err
+	}
+	record.Args = redactArgs(cmd.Args, u)
+
+	phases := []HookWhen{HookWhenPreLaunch, HookWhenPostLaunch}
+	if config.WaitForExit {
+		phases = append(phases, HookWhenPostExit)
+	}
+	if config.WaitForExit && config.CrashPolicy != nil {
+		phases = append(phases, HookWhenPostCrash)
+	}
+
+	for _, when := range phases {
+		hooks := selectHooks(config, handlers, when)
+		names := make([]string, 0, len(hooks))
+		for _, hc := range hooks {
+			names = append(names, hc.Handler)
+		}
+		log.Info().Str("when", string(when)).Strs("handlers", names).Msg("Dry run: would run hook handlers")
+	}
+
+	log.Info().
+		Str("path", cmd.Path).
+		Strs("args", redactArgs(cmd.Args, u)).
+		Str("dir", cmd.Dir).
+		Strs("env", toEnvList(config.Env)).
+		Msg("Dry run: would start game")
+
+	return nil
+}
 
-		err := handler.Run(l.repository, u, config, launchType, hc.Args)
+func (l *GameLauncher) startGame(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder) (*exec.Cmd, error) {
+	args, err := l.getArgs(u, config, launchType, cmdBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := buildCmd(config, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ReportDir != "" {
+		output, err2 := l.createOutputLog(config.ReportDir)
+		if err2 != nil {
+			return nil, err2
+		}
+		// The game gets its own handle, so ours can be closed as soon as the game has been started
+		defer output.Close()
+		cmd.Stdout = output
+		cmd.Stderr = output
+	}
+
+	log.Debug().Str("path", cmd.Path).Strs("args", redactArgs(cmd.Args, u)).Str("dir", cmd.Dir).Msg("Starting game")
+
+	if err = l.runner.Start(cmd); err != nil {
+		return nil, err
+	}
+
+	return cmd, nil
+}
+
+// createOutputLog Creates the report dir along with the file to capture the game's stdout and stderr in
+func (l *GameLauncher) createOutputLog(reportDir string) (*os.File, error) {
+	if err := l.repository.MkdirAll(reportDir, reportDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create report dir: %s", err)
+	}
+
+	output, err := l.repository.OpenFile(filepath.Join(reportDir, reportOutputLogName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, reportFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output log file: %s", err)
+	}
+
+	return output, nil
+}
+
+// collectLogFiles Copies the game's log files into the report dir. Errors are logged rather than returned, since
+// the game has already exited at this point (and may simply not have written all log files).
+func (l *GameLauncher) collectLogFiles(config Config) {
+	for _, pattern := range config.LogFiles {
+		matches, err := l.repository.Glob(filepath.Join(config.InstallPath, filepath.FromSlash(pattern)))
 		if err != nil {
-			log.Error().Err(err).Str(handlerLogKey, hc.Handler).Msg("Hook handler execution failed")
-			if hc.ExitOnError {
-				return err
+			log.Warn().Err(err).Str("pattern", pattern).Msg("Failed to find game log files")
+			continue
+		}
+
+		for _, match := range matches {
+			if err = l.collectLogFile(match, config.ReportDir); err != nil {
+				log.Warn().Err(err).Str("path", match).Msg("Failed to collect game log file")
 			}
 		}
 	}
+
+	log.Info().Str("dir", config.ReportDir).Msg("Saved launch report")
+}
+
+// collectLogFile Copies the given file into the report dir. Dirs are copied including any files (but not subdirs).
+func (l *GameLauncher) collectLogFile(path string, reportDir string) error {
+	isDir, err := l.repository.DirExists(path)
+	if err != nil {
+		return err
+	}
+
+	if !isDir {
+		return l.copyFile(path, filepath.Join(reportDir, filepath.Base(path)))
+	}
+
+	entries, err := l.repository.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(reportDir, filepath.Base(path))
+	if err = l.repository.MkdirAll(target, reportDirPerm); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if err = l.copyFile(filepath.Join(path, entry.Name()), filepath.Join(target, entry.Name())); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (l *GameLauncher) startGame(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder) error {
-	args, err := l.getArgs(u, config, launchType, cmdBuilder)
+func (l *GameLauncher) copyFile(src string, dst string) error {
+	data, err := l.repository.ReadFile(src)
 	if err != nil {
 		return err
 	}
+	return l.repository.WriteFile(dst, data, reportFilePerm)
+}
+
+// waitForGame Waits for the game process to exit, recording its exit code and runtime
+func (l *GameLauncher) waitForGame(cmd *exec.Cmd, startedAt time.Time) (GameExit, error) {
+	log.Info().Msg("Waiting for game to exit")
+
+	exitCode, err := l.runner.Wait(cmd)
+	if err != nil {
+		return GameExit{}, fmt.Errorf("failed to wait for game to exit: %s", err)
+	}
+
+	exit := GameExit{
+		ExitCode: exitCode,
+		Runtime:  l.now().Sub(startedAt),
+	}
+
+	log.Info().Int("exitCode", exit.ExitCode).Dur("runtime", exit.Runtime).Msg("Game exited")
+
+	return exit, nil
+}
 
-	path := filepath.Join(config.InstallPath, config.ExecutablePath, config.ExecutableName)
+func buildCmd(config Config, args []string) (*exec.Cmd, error) {
+	// Executable paths are defined with Windows separators, so convert them to whatever the host uses
+	executablePath := filepath.FromSlash(strings.ReplaceAll(config.ExecutablePath, "\\", "/"))
+	path := filepath.Join(config.InstallPath, executablePath, config.ExecutableName)
 
 	dir := config.InstallPath
 	if config.StartIn == LaunchDirBinaryDir {
@@ -146,11 +475,63 @@ func (l *GameLauncher) startGame(u *url.URL, config Config, launchType LaunchTyp
 		Args: append([]string{path}, args...),
 	}
 
-	return cmd.Start()
+	if len(config.Wrapper) > 0 {
+		// Run the game executable via the wrapper, passing the executable path as first argument
+		wrapperPath, err := exec.LookPath(config.Wrapper[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to find wrapper command %s: %s", config.Wrapper[0], err)
+		}
+		cmd.Path = wrapperPath
+		cmd.Args = append(append([]string{}, config.Wrapper...), cmd.Args...)
+	}
+
+	if len(config.Env) > 0 {
+		cmd.Env = append(os.Environ(), toEnvList(config.Env)...)
+	}
+
+	return cmd, nil
+}
+
+// redactArgs Masks the server password contained in the URL (if any) in the given arguments, which is either passed
+// as the argument following a password flag (+password <password>, +set password <password>) or as Unreal URL option
+func redactArgs(args []string, u *url.URL) []string {
+	password := u.Query().Get(urlQueryKeyPassword)
+	if password == "" {
+		return args
+	}
+
+	redacted := make([]string, 0, len(args))
+	for i, arg := range args {
+		if i > 0 && isPasswordFlag(args[i-1]) {
+			redacted = append(redacted, redactedValue)
+			continue
+		}
+		redacted = append(redacted, unrealURLPasswordRegex.ReplaceAllString(arg, "${1}"+redactedValue))
+	}
+	return redacted
+}
+
+func isPasswordFlag(arg string) bool {
+	return strings.EqualFold(strings.TrimLeft(arg, "+-"), urlQueryKeyPassword)
+}
+
+// toEnvList Converts environment variables to a slice of key=value pairs, sorted by key for a stable order
+func toEnvList(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, fmt.Sprintf("%s=%s", key, env[key]))
+	}
+	return list
 }
 
 func (l *GameLauncher) getArgs(u *url.URL, config Config, launchType LaunchType, cmdBuilder CommandBuilder) ([]string, error) {
-	args, err := cmdBuilder.GetArgs(l.repository, u, launchType)
+	args, err := cmdBuilder.GetArgs(l.repository, u, config, launchType)
 	if err != nil {
 		return nil, err
 	}
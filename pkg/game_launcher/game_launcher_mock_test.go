@@ -0,0 +1,349 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: game_launcher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=game_launcher.go -destination=game_launcher_mock_test.go -package=game_launcher -write_package_comment=false
+package game_launcher
+
+import (
+	url "net/url"
+	os "os"
+	exec "os/exec"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFileRepository is a mock of FileRepository interface.
+type MockFileRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileRepositoryMockRecorder
+}
+
+// MockFileRepositoryMockRecorder is the mock recorder for MockFileRepository.
+type MockFileRepositoryMockRecorder struct {
+	mock *MockFileRepository
+}
+
+// NewMockFileRepository creates a new mock instance.
+func NewMockFileRepository(ctrl *gomock.Controller) *MockFileRepository {
+	mock := &MockFileRepository{ctrl: ctrl}
+	mock.recorder = &MockFileRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileRepository) EXPECT() *MockFileRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DirExists mocks base method.
+func (m *MockFileRepository) DirExists(path string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DirExists", path)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DirExists indicates an expected call of DirExists.
+func (mr *MockFileRepositoryMockRecorder) DirExists(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DirExists", reflect.TypeOf((*MockFileRepository)(nil).DirExists), path)
+}
+
+// FileExists mocks base method.
+func (m *MockFileRepository) FileExists(path string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileExists", path)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileExists indicates an expected call of FileExists.
+func (mr *MockFileRepositoryMockRecorder) FileExists(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileExists", reflect.TypeOf((*MockFileRepository)(nil).FileExists), path)
+}
+
+// Glob mocks base method.
+func (m *MockFileRepository) Glob(pattern string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Glob", pattern)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Glob indicates an expected call of Glob.
+func (mr *MockFileRepositoryMockRecorder) Glob(pattern any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Glob", reflect.TypeOf((*MockFileRepository)(nil).Glob), pattern)
+}
+
+// MkdirAll mocks base method.
+func (m *MockFileRepository) MkdirAll(path string, perm os.FileMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MkdirAll", path, perm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MkdirAll indicates an expected call of MkdirAll.
+func (mr *MockFileRepositoryMockRecorder) MkdirAll(path, perm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MkdirAll", reflect.TypeOf((*MockFileRepository)(nil).MkdirAll), path, perm)
+}
+
+// OpenFile mocks base method.
+func (m *MockFileRepository) OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", path, flag, perm)
+	ret0, _ := ret[0].(*os.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockFileRepositoryMockRecorder) OpenFile(path, flag, perm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockFileRepository)(nil).OpenFile), path, flag, perm)
+}
+
+// ReadDir mocks base method.
+func (m *MockFileRepository) ReadDir(path string) ([]os.DirEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDir", path)
+	ret0, _ := ret[0].([]os.DirEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadDir indicates an expected call of ReadDir.
+func (mr *MockFileRepositoryMockRecorder) ReadDir(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDir", reflect.TypeOf((*MockFileRepository)(nil).ReadDir), path)
+}
+
+// ReadFile mocks base method.
+func (m *MockFileRepository) ReadFile(path string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile.
+func (mr *MockFileRepositoryMockRecorder) ReadFile(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockFileRepository)(nil).ReadFile), path)
+}
+
+// RemoveAll mocks base method.
+func (m *MockFileRepository) RemoveAll(path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAll", path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAll indicates an expected call of RemoveAll.
+func (mr *MockFileRepositoryMockRecorder) RemoveAll(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAll", reflect.TypeOf((*MockFileRepository)(nil).RemoveAll), path)
+}
+
+// WriteFile mocks base method.
+func (m *MockFileRepository) WriteFile(path string, data []byte, perm os.FileMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFile", path, data, perm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteFile indicates an expected call of WriteFile.
+func (mr *MockFileRepositoryMockRecorder) WriteFile(path, data, perm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFile", reflect.TypeOf((*MockFileRepository)(nil).WriteFile), path, data, perm)
+}
+
+// MockProcessRunner is a mock of ProcessRunner interface.
+type MockProcessRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessRunnerMockRecorder
+}
+
+// MockProcessRunnerMockRecorder is the mock recorder for MockProcessRunner.
+type MockProcessRunnerMockRecorder struct {
+	mock *MockProcessRunner
+}
+
+// NewMockProcessRunner creates a new mock instance.
+func NewMockProcessRunner(ctrl *gomock.Controller) *MockProcessRunner {
+	mock := &MockProcessRunner{ctrl: ctrl}
+	mock.recorder = &MockProcessRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessRunner) EXPECT() *MockProcessRunnerMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockProcessRunner) Start(cmd *exec.Cmd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockProcessRunnerMockRecorder) Start(cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockProcessRunner)(nil).Start), cmd)
+}
+
+// Wait mocks base method.
+func (m *MockProcessRunner) Wait(cmd *exec.Cmd) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", cmd)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockProcessRunnerMockRecorder) Wait(cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockProcessRunner)(nil).Wait), cmd)
+}
+
+// MockURLValidator is a mock of URLValidator interface.
+type MockURLValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLValidatorMockRecorder
+}
+
+// MockURLValidatorMockRecorder is the mock recorder for MockURLValidator.
+type MockURLValidatorMockRecorder struct {
+	mock *MockURLValidator
+}
+
+// NewMockURLValidator creates a new mock instance.
+func NewMockURLValidator(ctrl *gomock.Controller) *MockURLValidator {
+	mock := &MockURLValidator{ctrl: ctrl}
+	mock.recorder = &MockURLValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLValidator) EXPECT() *MockURLValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockURLValidator) Validate(u *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockURLValidatorMockRecorder) Validate(u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockURLValidator)(nil).Validate), u)
+}
+
+// MockCommandBuilder is a mock of CommandBuilder interface.
+type MockCommandBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandBuilderMockRecorder
+}
+
+// MockCommandBuilderMockRecorder is the mock recorder for MockCommandBuilder.
+type MockCommandBuilderMockRecorder struct {
+	mock *MockCommandBuilder
+}
+
+// NewMockCommandBuilder creates a new mock instance.
+func NewMockCommandBuilder(ctrl *gomock.Controller) *MockCommandBuilder {
+	mock := &MockCommandBuilder{ctrl: ctrl}
+	mock.recorder = &MockCommandBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandBuilder) EXPECT() *MockCommandBuilderMockRecorder {
+	return m.recorder
+}
+
+// GetArgs mocks base method.
+func (m *MockCommandBuilder) GetArgs(fr FileRepository, u *url.URL, config Config, launchType LaunchType) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgs", fr, u, config, launchType)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgs indicates an expected call of GetArgs.
+func (mr *MockCommandBuilderMockRecorder) GetArgs(fr, u, config, launchType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgs", reflect.TypeOf((*MockCommandBuilder)(nil).GetArgs), fr, u, config, launchType)
+}
+
+// MockHookHandler is a mock of HookHandler interface.
+type MockHookHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHookHandlerMockRecorder
+}
+
+// MockHookHandlerMockRecorder is the mock recorder for MockHookHandler.
+type MockHookHandlerMockRecorder struct {
+	mock *MockHookHandler
+}
+
+// NewMockHookHandler creates a new mock instance.
+func NewMockHookHandler(ctrl *gomock.Controller) *MockHookHandler {
+	mock := &MockHookHandler{ctrl: ctrl}
+	mock.recorder = &MockHookHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHookHandler) EXPECT() *MockHookHandlerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockHookHandler) Run(fr FileRepository, u *url.URL, config Config, launchType LaunchType, args map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", fr, u, config, launchType, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockHookHandlerMockRecorder) Run(fr, u, config, launchType, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockHookHandler)(nil).Run), fr, u, config, launchType, args)
+}
+
+// String mocks base method.
+func (m *MockHookHandler) String() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "String")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// String indicates an expected call of String.
+func (mr *MockHookHandlerMockRecorder) String() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockHookHandler)(nil).String))
+}
@@ -0,0 +1,792 @@
+//go:build unit
+
+package game_launcher
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBuildCmd(t *testing.T) {
+	// Use the test binary as wrapper, since it is guaranteed to exist on any platform
+	wrapperPath, err := filepath.Abs(os.Args[0])
+	require.NoError(t, err)
+
+	installPath := filepath.Join("games", "some-game")
+	exePath := filepath.Join(installPath, "bin", "game.exe")
+
+	type test struct {
+		name            string
+		givenConfig     Config
+		givenArgs       []string
+		wantPath        string
+		wantArgs        []string
+		wantDir         string
+		wantEnv         []string
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name: "builds command for executable",
+			givenConfig: Config{
+				ExecutableName: "game.exe",
+				ExecutablePath: "bin",
+				InstallPath:    installPath,
+			},
+			givenArgs: []string{"+connect", "1.1.1.1:16567"},
+			wantPath:  exePath,
+			wantArgs:  []string{exePath, "+connect", "1.1.1.1:16567"},
+			wantDir:   installPath,
+		},
+		{
+			name: "builds command for executable in binary dir",
+			givenConfig: Config{
+				StartIn:        LaunchDirBinaryDir,
+				ExecutableName: "game.exe",
+				ExecutablePath: "bin",
+				InstallPath:    installPath,
+			},
+			wantPath: exePath,
+			wantArgs: []string{exePath},
+			wantDir:  filepath.Join(installPath, "bin"),
+		},
+		{
+			name: "builds command for executable path with backslashes",
+			givenConfig: Config{
+				ExecutableName: "game.exe",
+				ExecutablePath: "Content\\System",
+				InstallPath:    installPath,
+			},
+			wantPath: filepath.Join(installPath, "Content", "System", "game.exe"),
+			wantArgs: []string{filepath.Join(installPath, "Content", "System", "game.exe")},
+			wantDir:  installPath,
+		},
+		{
+			name: "builds command with wrapper and env",
+			givenConfig: Config{
+				ExecutableName: "game.exe",
+				ExecutablePath: "bin",
+				InstallPath:    installPath,
+				Wrapper:        []string{wrapperPath, "run"},
+				Env: map[string]string{
+					"WINEPREFIX":       "/home/user/.wine",
+					"WINEDLLOVERRIDES": "d3d9=n,b",
+				},
+			},
+			givenArgs: []string{"+connect", "1.1.1.1:16567"},
+			wantPath:  wrapperPath,
+			wantArgs:  []string{wrapperPath, "run", exePath, "+connect", "1.1.1.1:16567"},
+			wantDir:   installPath,
+			wantEnv:   append(os.Environ(), "WINEDLLOVERRIDES=d3d9=n,b", "WINEPREFIX=/home/user/.wine"),
+		},
+		{
+			name: "errors for non-existing wrapper",
+			givenConfig: Config{
+				ExecutableName: "game.exe",
+				InstallPath:    installPath,
+				Wrapper:        []string{"not-a-wrapper-command"},
+			},
+			wantErrContains: "failed to find wrapper command not-a-wrapper-command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			cmd, err := buildCmd(tt.givenConfig, tt.givenArgs)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantPath, cmd.Path)
+				assert.Equal(t, tt.wantArgs, cmd.Args)
+				assert.Equal(t, tt.wantDir, cmd.Dir)
+				assert.Equal(t, tt.wantEnv, cmd.Env)
+			}
+		})
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	type test struct {
+		name       string
+		givenArgs  []string
+		givenQuery string
+		wantArgs   []string
+	}
+
+	tests := []test{
+		{
+			name:       "masks password in separate argument",
+			givenArgs:  []string{"+connect", "1.1.1.1:28960", "+set", "password", "secret"},
+			givenQuery: "password=secret",
+			wantArgs:   []string{"+connect", "1.1.1.1:28960", "+set", "password", "xxxxx"},
+		},
+		{
+			name:       "masks password within argument",
+			givenArgs:  []string{"1.1.1.1:7777?password=secret"},
+			givenQuery: "password=secret",
+			wantArgs:   []string{"1.1.1.1:7777?password=xxxxx"},
+		},
+		{
+			name:       "masks password following password flag",
+			givenArgs:  []string{"+join", "1.1.1.1:27888", "+password", "secret"},
+			givenQuery: "password=secret",
+			wantArgs:   []string{"+join", "1.1.1.1:27888", "+password", "xxxxx"},
+		},
+		{
+			name:       "masks password containing special characters within argument",
+			givenArgs:  []string{"1.1.1.1:7777?password=se&cr%t=1?name=player"},
+			givenQuery: "password=" + url.QueryEscape("se&cr%t=1"),
+			wantArgs:   []string{"1.1.1.1:7777?password=xxxxx?name=player"},
+		},
+		{
+			name:       "does not modify unrelated arguments containing password",
+			givenArgs:  []string{"+set", "fs_game", "mods/pam", "+connect", "1.1.1.1:28960", "+set", "password", "pam"},
+			givenQuery: "password=pam",
+			wantArgs:   []string{"+set", "fs_game", "mods/pam", "+connect", "1.1.1.1:28960", "+set", "password", "xxxxx"},
+		},
+		{
+			name:       "returns arguments as is without password",
+			givenArgs:  []string{"+connect", "1.1.1.1:28960"},
+			givenQuery: "mod=some-mod",
+			wantArgs:   []string{"+connect", "1.1.1.1:28960"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			u := &url.URL{Host: "1.1.1.1:28960", RawQuery: tt.givenQuery}
+
+			// WHEN
+			args := redactArgs(tt.givenArgs, u)
+
+			// THEN
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestGameLauncher_StartGame_DryRun(t *testing.T) {
+	t.Run("builds args without running hooks or starting the game", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		mockCmdBuilder := NewMockCommandBuilder(ctrl)
+		mockHookHandler := NewMockHookHandler(ctrl)
+		launcher := New(mockRepository, NewMockProcessRunner(ctrl), true)
+		u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:16567"}
+		config := Config{
+			ExecutableName: "game.exe",
+			// Executable does not exist, so the game could not possibly be started
+			InstallPath: filepath.Join("games", "some-game"),
+			HookConfigs: []HookConfig{
+				{
+					Handler: "some-hook",
+					When:    HookWhenPreLaunch,
+				},
+			},
+		}
+
+		// EXPECT
+		mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"+connect", "1.1.1.1:16567"}, nil)
+		mockHookHandler.EXPECT().String().Return("some-hook").AnyTimes()
+
+		// WHEN
+		_, err := launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder, mockHookHandler)
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func TestGameLauncher_StartGame(t *testing.T) {
+	t.Run("runs hooks around starting the game via the process runner", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		mockRunner := NewMockProcessRunner(ctrl)
+		mockCmdBuilder := NewMockCommandBuilder(ctrl)
+		mockPreLaunchHook := NewMockHookHandler(ctrl)
+		mockPostLaunchHook := NewMockHookHandler(ctrl)
+		launcher := New(mockRepository, mockRunner, false)
+		u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:16567"}
+		installPath := filepath.Join("games", "some-game")
+		exePath := filepath.Join(installPath, "game.exe")
+		config := Config{
+			DefaultArgs:    []string{"+restart", "1"},
+			ExecutableName: "game.exe",
+			InstallPath:    installPath,
+			HookConfigs: []HookConfig{
+				{
+					Handler: "pre-launch-hook",
+					When:    HookWhenPreLaunch,
+				},
+				{
+					Handler: "post-launch-hook",
+					When:    HookWhenPostLaunch,
+				},
+			},
+		}
+
+		// EXPECT
+		mockPreLaunchHook.EXPECT().String().Return("pre-launch-hook").AnyTimes()
+		mockPostLaunchHook.EXPECT().String().Return("post-launch-hook").AnyTimes()
+		gomock.InOrder(
+			mockPreLaunchHook.EXPECT().Run(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin), gomock.Nil()).Return(nil),
+			mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"+connect", "1.1.1.1:16567"}, nil),
+			mockRunner.EXPECT().Start(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) error {
+				assert.Equal(t, exePath, cmd.Path)
+				assert.Equal(t, []string{exePath, "+restart", "1", "+connect", "1.1.1.1:16567"}, cmd.Args)
+				assert.Equal(t, installPath, cmd.Dir)
+				return nil
+			}),
+			mockPostLaunchHook.EXPECT().Run(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin), gomock.Nil()).Return(nil),
+		)
+
+		// WHEN
+		_, err := launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder, mockPreLaunchHook, mockPostLaunchHook)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("waits for game to exit and runs post-exit hooks in supervised mode", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		mockRunner := NewMockProcessRunner(ctrl)
+		mockCmdBuilder := NewMockCommandBuilder(ctrl)
+		mockPostLaunchHook := NewMockHookHandler(ctrl)
+		mockPostExitHook := NewMockHookHandler(ctrl)
+		launcher := New(mockRepository, mockRunner, false)
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		launcher.now = func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		}
+		u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:16567"}
+		config := Config{
+			ExecutableName: "game.exe",
+			InstallPath:    filepath.Join("games", "some-game"),
+			HookConfigs: []HookConfig{
+				{
+					Handler: "post-launch-hook",
+					When:    HookWhenPostLaunch,
+				},
+				{
+					Handler: "post-exit-hook",
+					When:    HookWhenPostExit,
+				},
+			},
+			WaitForExit: true,
+		}
+
+		// EXPECT
+		mockPostLaunchHook.EXPECT().String().Return("post-launch-hook").AnyTimes()
+		mockPostExitHook.EXPECT().String().Return("post-exit-hook").AnyTimes()
+		mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"+connect", "1.1.1.1:16567"}, nil)
+		gomock.InOrder(
+			mockRunner.EXPECT().Start(gomock.Any()).Return(nil),
+			mockPostLaunchHook.EXPECT().Run(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin), gomock.Nil()).Return(nil),
+			mockRunner.EXPECT().Wait(gomock.Any()).Return(1, nil),
+			mockPostExitHook.EXPECT().Run(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin), gomock.Nil()).Return(nil),
+		)
+
+		// WHEN
+		record, err := launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder, mockPostLaunchHook, mockPostExitHook)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("games", "some-game", "game.exe"), "+connect", "1.1.1.1:16567"}, record.Args)
+		assert.Equal(t, []HookRecord{
+			{Handler: "post-launch-hook", When: HookWhenPostLaunch, Duration: time.Second},
+			{Handler: "post-exit-hook", When: HookWhenPostExit, Duration: time.Second},
+		}, record.Hooks)
+		require.NotNil(t, record.Exit)
+		assert.Equal(t, 1, record.Exit.ExitCode)
+		assert.Equal(t, 0, record.Relaunches)
+	})
+
+	t.Run("does not run post-exit hooks if waiting for game fails", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		mockRunner := NewMockProcessRunner(ctrl)
+		mockCmdBuilder := NewMockCommandBuilder(ctrl)
+		mockPostExitHook := NewMockHookHandler(ctrl)
+		launcher := New(mockRepository, mockRunner, false)
+		u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:16567"}
+		config := Config{
+			ExecutableName: "game.exe",
+			InstallPath:    filepath.Join("games", "some-game"),
+			HookConfigs: []HookConfig{
+				{
+					Handler: "post-exit-hook",
+					When:    HookWhenPostExit,
+				},
+			},
+			WaitForExit: true,
+		}
+
+		// EXPECT
+		mockPostExitHook.EXPECT().String().Return("post-exit-hook").AnyTimes()
+		mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"+connect", "1.1.1.1:16567"}, nil)
+		mockRunner.EXPECT().Start(gomock.Any()).Return(nil)
+		mockRunner.EXPECT().Wait(gomock.Any()).Return(0, fmt.Errorf("some-error"))
+
+		// WHEN
+		_, err := launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder, mockPostExitHook)
+
+		// THEN
+		require.ErrorContains(t, err, "failed to wait for game to exit: some-error")
+	})
+
+	t.Run("errors if process runner fails to start the game", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		mockRunner := NewMockProcessRunner(ctrl)
+		mockCmdBuilder := NewMockCommandBuilder(ctrl)
+		launcher := New(mockRepository, mockRunner, false)
+		u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:16567"}
+		config := Config{
+			ExecutableName: "game.exe",
+			InstallPath:    filepath.Join("games", "some-game"),
+		}
+
+		// EXPECT
+		mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"+connect", "1.1.1.1:16567"}, nil)
+		mockRunner.EXPECT().Start(gomock.Any()).Return(os.ErrNotExist)
+
+		// WHEN
+		_, err := launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder)
+
+		// THEN
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestSelectHooks(t *testing.T) {
+	type test struct {
+		name        string
+		givenWhen   HookWhen
+		wantHandler []string
+	}
+
+	config := Config{
+		HookConfigs: []HookConfig{
+			{Handler: "pre-launch-hook", When: HookWhenPreLaunch},
+			{Handler: "post-launch-hook", When: HookWhenPostLaunch},
+			{Handler: "post-exit-hook", When: HookWhenPostExit},
+			{Handler: "post-crash-hook", When: HookWhenPostCrash},
+			{Handler: "always-hook", When: HookWhenAlways},
+			{Handler: "never-hook", When: HookWhenNever},
+			{Handler: "unknown-hook", When: HookWhenAlways},
+		},
+	}
+
+	tests := []test{
+		{
+			name:        "selects pre-launch and always hooks",
+			givenWhen:   HookWhenPreLaunch,
+			wantHandler: []string{"pre-launch-hook", "always-hook"},
+		},
+		{
+			name:        "selects post-launch and always hooks",
+			givenWhen:   HookWhenPostLaunch,
+			wantHandler: []string{"post-launch-hook", "always-hook"},
+		},
+		{
+			name:        "selects post-exit hooks only",
+			givenWhen:   HookWhenPostExit,
+			wantHandler: []string{"post-exit-hook"},
+		},
+		{
+			name:        "selects post-crash hooks only",
+			givenWhen:   HookWhenPostCrash,
+			wantHandler: []string{"post-crash-hook"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			handlers := map[string]HookHandler{}
+			for _, name := range []string{"pre-launch-hook", "post-launch-hook", "post-exit-hook", "post-crash-hook", "always-hook", "never-hook"} {
+				handlers[name] = NewMockHookHandler(ctrl)
+			}
+
+			// WHEN
+			hooks := selectHooks(config, handlers, tt.givenWhen)
+
+			// THEN
+			names := make([]string, 0, len(hooks))
+			for _, hc := range hooks {
+				names = append(names, hc.Handler)
+			}
+			assert.Equal(t, tt.wantHandler, names)
+		})
+	}
+}
+
+func TestGameLauncher_StartGame_CrashPolicy(t *testing.T) {
+	type test struct {
+		name            string
+		givenExitCodes  []int
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name:           "does not relaunch game which exited normally",
+			givenExitCodes: []int{0},
+		},
+		{
+			name:           "runs post-crash hooks and relaunches crashed game",
+			givenExitCodes: []int{1, 0},
+		},
+		{
+			name:            "gives up if game keeps crashing",
+			givenExitCodes:  []int{1, 1},
+			wantErrContains: "game crashed (exit code 1 after 30s), giving up after 1 relaunch attempt(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			mockRunner := NewMockProcessRunner(ctrl)
+			mockCmdBuilder := NewMockCommandBuilder(ctrl)
+			mockPostCrashHook := NewMockHookHandler(ctrl)
+			launcher := New(mockRepository, mockRunner, false)
+			// Time advances by 30 seconds whenever it is checked, so every session lasts 30 seconds
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			launcher.now = func() time.Time {
+				now = now.Add(30 * time.Second)
+				return now
+			}
+			u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:16567"}
+			config := Config{
+				ExecutableName: "game.exe",
+				InstallPath:    filepath.Join("games", "some-game"),
+				HookConfigs: []HookConfig{
+					{
+						Handler: "post-crash-hook",
+						When:    HookWhenPostCrash,
+					},
+				},
+				WaitForExit: true,
+				CrashPolicy: &CrashPolicy{
+					MinRuntime: 5 * time.Second,
+					MaxRetries: 1,
+				},
+			}
+
+			// EXPECT
+			mockPostCrashHook.EXPECT().String().Return("post-crash-hook").AnyTimes()
+			calls := make([]any, 0, len(tt.givenExitCodes)*4)
+			for i, exitCode := range tt.givenExitCodes {
+				calls = append(calls,
+					mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"+connect", "1.1.1.1:16567"}, nil),
+					mockRunner.EXPECT().Start(gomock.Any()).Return(nil),
+					mockRunner.EXPECT().Wait(gomock.Any()).Return(exitCode, nil),
+				)
+				// Post-crash hooks only run before relaunching
+				if exitCode != 0 && i < len(tt.givenExitCodes)-1 {
+					calls = append(calls, mockPostCrashHook.EXPECT().Run(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin), gomock.Nil()).Return(nil))
+				}
+			}
+			gomock.InOrder(calls...)
+
+			// WHEN
+			_, err := launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder, mockPostCrashHook)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIsCrash(t *testing.T) {
+	type test struct {
+		name        string
+		givenExit   GameExit
+		givenPolicy *CrashPolicy
+		wantCrash   bool
+	}
+
+	policy := &CrashPolicy{MinRuntime: 5 * time.Second}
+	tests := []test{
+		{
+			name:        "true for non-zero exit code",
+			givenExit:   GameExit{ExitCode: 1, Runtime: time.Hour},
+			givenPolicy: policy,
+			wantCrash:   true,
+		},
+		{
+			name:        "true for runtime shorter than minimum runtime",
+			givenExit:   GameExit{ExitCode: 0, Runtime: 2 * time.Second},
+			givenPolicy: policy,
+			wantCrash:   true,
+		},
+		{
+			name:        "false for zero exit code after minimum runtime",
+			givenExit:   GameExit{ExitCode: 0, Runtime: time.Hour},
+			givenPolicy: policy,
+			wantCrash:   false,
+		},
+		{
+			name:        "false without policy",
+			givenExit:   GameExit{ExitCode: 1, Runtime: 2 * time.Second},
+			givenPolicy: nil,
+			wantCrash:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			crash := isCrash(tt.givenExit, tt.givenPolicy)
+
+			// THEN
+			assert.Equal(t, tt.wantCrash, crash)
+		})
+	}
+}
+
+func TestGameLauncher_StartGame_LaunchReport(t *testing.T) {
+	t.Run("captures output and collects log files into report dir", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRepository := NewMockFileRepository(ctrl)
+		mockRunner := NewMockProcessRunner(ctrl)
+		mockCmdBuilder := NewMockCommandBuilder(ctrl)
+		launcher := New(mockRepository, mockRunner, false)
+		u := &url.URL{Scheme: "some-game", Host: "1.1.1.1:7777"}
+		installPath := filepath.Join("games", "some-game")
+		reportDir := filepath.Join("reports", "some-game-20240101-120000")
+		config := Config{
+			ExecutableName: "game.exe",
+			InstallPath:    installPath,
+			WaitForExit:    true,
+			ReportDir:      reportDir,
+			LogFiles:       []string{"System/game.log"},
+		}
+		logFilePath := filepath.Join(installPath, "System", "game.log")
+		output, err := os.Create(filepath.Join(t.TempDir(), "output.log"))
+		require.NoError(t, err)
+
+		// EXPECT
+		mockCmdBuilder.EXPECT().GetArgs(gomock.Eq(mockRepository), gomock.Eq(u), gomock.Eq(config), gomock.Eq(LaunchTypeLaunchAndJoin)).Return([]string{"1.1.1.1:7777"}, nil)
+		mockRepository.EXPECT().MkdirAll(gomock.Eq(reportDir), gomock.Eq(os.FileMode(0755))).Return(nil)
+		mockRepository.EXPECT().OpenFile(gomock.Eq(filepath.Join(reportDir, "output.log")), gomock.Eq(os.O_CREATE|os.O_WRONLY|os.O_APPEND), gomock.Eq(os.FileMode(0644))).Return(output, nil)
+		mockRunner.EXPECT().Start(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) error {
+			assert.Equal(t, output, cmd.Stdout)
+			assert.Equal(t, output, cmd.Stderr)
+			return nil
+		})
+		mockRunner.EXPECT().Wait(gomock.Any()).Return(0, nil)
+		mockRepository.EXPECT().Glob(gomock.Eq(logFilePath)).Return([]string{logFilePath}, nil)
+		mockRepository.EXPECT().DirExists(gomock.Eq(logFilePath)).Return(false, nil)
+		mockRepository.EXPECT().ReadFile(gomock.Eq(logFilePath)).Return([]byte("some-log"), nil)
+		mockRepository.EXPECT().WriteFile(gomock.Eq(filepath.Join(reportDir, "game.log")), gomock.Eq([]byte("some-log")), gomock.Eq(os.FileMode(0644))).Return(nil)
+
+		// WHEN
+		_, err = launcher.StartGame(u, config, LaunchTypeLaunchAndJoin, mockCmdBuilder)
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func TestGameLauncher_CreateOutputLog(t *testing.T) {
+	type test struct {
+		name            string
+		expect          func(repository *MockFileRepository, output *os.File)
+		wantErrContains string
+	}
+
+	reportDir := filepath.Join("reports", "some-game-20240101-120000")
+	outputPath := filepath.Join(reportDir, "output.log")
+
+	tests := []test{
+		{
+			name: "creates report dir and output log file",
+			expect: func(repository *MockFileRepository, output *os.File) {
+				repository.EXPECT().MkdirAll(gomock.Eq(reportDir), gomock.Eq(os.FileMode(0755))).Return(nil)
+				repository.EXPECT().OpenFile(gomock.Eq(outputPath), gomock.Eq(os.O_CREATE|os.O_WRONLY|os.O_APPEND), gomock.Eq(os.FileMode(0644))).Return(output, nil)
+			},
+		},
+		{
+			name: "errors if report dir cannot be created",
+			expect: func(repository *MockFileRepository, output *os.File) {
+				repository.EXPECT().MkdirAll(gomock.Eq(reportDir), gomock.Eq(os.FileMode(0755))).Return(fmt.Errorf("some-error"))
+			},
+			wantErrContains: "failed to create report dir: some-error",
+		},
+		{
+			name: "errors if output log file cannot be created",
+			expect: func(repository *MockFileRepository, output *os.File) {
+				repository.EXPECT().MkdirAll(gomock.Eq(reportDir), gomock.Eq(os.FileMode(0755))).Return(nil)
+				repository.EXPECT().OpenFile(gomock.Eq(outputPath), gomock.Eq(os.O_CREATE|os.O_WRONLY|os.O_APPEND), gomock.Eq(os.FileMode(0644))).Return(nil, fmt.Errorf("some-error"))
+			},
+			wantErrContains: "failed to create output log file: some-error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			launcher := New(mockRepository, NewMockProcessRunner(ctrl), false)
+			givenOutput, err := os.Create(filepath.Join(t.TempDir(), "output.log"))
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = givenOutput.Close()
+			})
+
+			// EXPECT
+			tt.expect(mockRepository, givenOutput)
+
+			// WHEN
+			output, err := launcher.createOutputLog(reportDir)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+				assert.Nil(t, output)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, givenOutput, output)
+			}
+		})
+	}
+}
+
+func TestGameLauncher_CollectLogFile(t *testing.T) {
+	logDir := filepath.Join(t.TempDir(), "logs")
+	require.NoError(t, os.MkdirAll(filepath.Join(logDir, "subdir"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "game.log"), []byte("some-log"), 0644))
+	entries, err := os.ReadDir(logDir)
+	require.NoError(t, err)
+	logFile := filepath.Join("games", "some-game", "game.log")
+	reportDir := filepath.Join("reports", "some-game-20240101-120000")
+
+	type test struct {
+		name            string
+		givenPath       string
+		expect          func(repository *MockFileRepository)
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name:      "collects log file",
+			givenPath: logFile,
+			expect: func(repository *MockFileRepository) {
+				repository.EXPECT().DirExists(gomock.Eq(logFile)).Return(false, nil)
+				repository.EXPECT().ReadFile(gomock.Eq(logFile)).Return([]byte("some-log"), nil)
+				repository.EXPECT().WriteFile(gomock.Eq(filepath.Join(reportDir, "game.log")), gomock.Eq([]byte("some-log")), gomock.Eq(os.FileMode(0644))).Return(nil)
+			},
+		},
+		{
+			name:      "collects files contained in log dir",
+			givenPath: logDir,
+			expect: func(repository *MockFileRepository) {
+				repository.EXPECT().DirExists(gomock.Eq(logDir)).Return(true, nil)
+				repository.EXPECT().ReadDir(gomock.Eq(logDir)).Return(entries, nil)
+				repository.EXPECT().MkdirAll(gomock.Eq(filepath.Join(reportDir, "logs")), gomock.Eq(os.FileMode(0755))).Return(nil)
+				// Subdirs are skipped
+				repository.EXPECT().ReadFile(gomock.Eq(filepath.Join(logDir, "game.log"))).Return([]byte("some-log"), nil)
+				repository.EXPECT().WriteFile(gomock.Eq(filepath.Join(reportDir, "logs", "game.log")), gomock.Eq([]byte("some-log")), gomock.Eq(os.FileMode(0644))).Return(nil)
+			},
+		},
+		{
+			name:      "errors if log dir cannot be created in report dir",
+			givenPath: logDir,
+			expect: func(repository *MockFileRepository) {
+				repository.EXPECT().DirExists(gomock.Eq(logDir)).Return(true, nil)
+				repository.EXPECT().ReadDir(gomock.Eq(logDir)).Return(entries, nil)
+				repository.EXPECT().MkdirAll(gomock.Eq(filepath.Join(reportDir, "logs")), gomock.Eq(os.FileMode(0755))).Return(fmt.Errorf("some-error"))
+			},
+			wantErrContains: "some-error",
+		},
+		{
+			name:      "errors if log file cannot be read",
+			givenPath: logFile,
+			expect: func(repository *MockFileRepository) {
+				repository.EXPECT().DirExists(gomock.Eq(logFile)).Return(false, nil)
+				repository.EXPECT().ReadFile(gomock.Eq(logFile)).Return(nil, fmt.Errorf("some-error"))
+			},
+			wantErrContains: "some-error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			launcher := New(mockRepository, NewMockProcessRunner(ctrl), false)
+
+			// EXPECT
+			tt.expect(mockRepository)
+
+			// WHEN
+			err := launcher.collectLogFile(tt.givenPath, reportDir)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGameLauncher_WaitForGame(t *testing.T) {
+	t.Run("records exit code and runtime", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		mockRunner := NewMockProcessRunner(ctrl)
+		launcher := New(NewMockFileRepository(ctrl), mockRunner, false)
+		startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		launcher.now = func() time.Time {
+			return startedAt.Add(90 * time.Second)
+		}
+		cmd := &exec.Cmd{}
+
+		// EXPECT
+		mockRunner.EXPECT().Wait(gomock.Eq(cmd)).Return(3, nil)
+
+		// WHEN
+		exit, err := launcher.waitForGame(cmd, startedAt)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, GameExit{ExitCode: 3, Runtime: 90 * time.Second}, exit)
+	})
+}
@@ -0,0 +1,5 @@
+//go:build ignore
+
+package game_launcher
+
+//go:generate mockgen -source=game_launcher.go -destination=game_launcher_mock_test.go -package=$GOPACKAGE -write_package_comment=false
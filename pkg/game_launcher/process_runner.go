@@ -0,0 +1,27 @@
+package game_launcher
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// ExecProcessRunner Starts and waits for processes using os/exec
+type ExecProcessRunner struct{}
+
+func NewExecProcessRunner() *ExecProcessRunner {
+	return &ExecProcessRunner{}
+}
+
+func (r *ExecProcessRunner) Start(cmd *exec.Cmd) error {
+	return cmd.Start()
+}
+
+func (r *ExecProcessRunner) Wait(cmd *exec.Cmd) (int, error) {
+	err := cmd.Wait()
+	// Exiting with a non-zero code is a valid outcome, not an error to wait for the process
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return 0, err
+	}
+	return cmd.ProcessState.ExitCode(), nil
+}
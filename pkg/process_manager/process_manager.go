@@ -0,0 +1,55 @@
+package process_manager
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/mitchellh/go-ps"
+)
+
+type Process struct {
+	PID        int
+	Executable string
+}
+
+// ProcessManager Lists and kills processes using the platform's native process APIs (via go-ps)
+type ProcessManager struct{}
+
+func New() *ProcessManager {
+	return &ProcessManager{}
+}
+
+// Processes Returns all processes currently running on the system
+func (m *ProcessManager) Processes() ([]Process, error) {
+	processes, err := ps.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Process, 0, len(processes))
+	for _, process := range processes {
+		result = append(result, Process{
+			PID:        process.Pid(),
+			Executable: executableName(process),
+		})
+	}
+	return result, nil
+}
+
+// IsRunning Checks whether a process with the given pid exists
+func (m *ProcessManager) IsRunning(pid int) (bool, error) {
+	process, err := ps.FindProcess(pid)
+	if err != nil {
+		return false, err
+	}
+	return process != nil, nil
+}
+
+// Kill Force-kills the process with the given pid
+func (m *ProcessManager) Kill(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(syscall.SIGKILL)
+}
@@ -0,0 +1,65 @@
+package process_manager
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/mitchellh/go-ps"
+)
+
+const (
+	procDir = "/proc"
+	// Maximum length of the command name in /proc/<pid>/stat (TASK_COMM_LEN minus the terminating null byte)
+	maxCommLength = 15
+)
+
+// Terminate Asks the process with the given pid to exit by sending SIGTERM
+func (m *ProcessManager) Terminate(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(syscall.SIGTERM)
+}
+
+// executableName Returns the process' full executable name based on its command line, since go-ps reports the
+// command name from /proc/<pid>/stat, which the kernel truncates to 15 characters
+func executableName(process ps.Process) string {
+	cmdline, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(process.Pid()), "cmdline"))
+	if err != nil {
+		// Process may have exited in the meantime, or we are not allowed to read its command line
+		return process.Executable()
+	}
+
+	return executableFromCmdline(cmdline, process.Executable())
+}
+
+func executableFromCmdline(cmdline []byte, comm string) string {
+	if len(comm) < maxCommLength {
+		// Command name was not truncated
+		return comm
+	}
+
+	arg0, _, _ := bytes.Cut(cmdline, []byte{0})
+	if len(arg0) == 0 {
+		// Kernel threads and zombies do not have a command line
+		return comm
+	}
+
+	// Processes running via Wine use Windows paths, so split on either separator
+	name := string(arg0)
+	if i := strings.LastIndexAny(name, "/\\"); i != -1 {
+		name = name[i+1:]
+	}
+
+	// Processes can overwrite their command line, only use it if it still matches the (truncated) command name
+	if !strings.HasPrefix(name, comm) {
+		return comm
+	}
+
+	return name
+}
@@ -0,0 +1,67 @@
+//go:build unit
+
+package process_manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutableFromCmdline(t *testing.T) {
+	type test struct {
+		name               string
+		givenCmdline       string
+		givenComm          string
+		expectedExecutable string
+	}
+
+	tests := []test{
+		{
+			name:               "returns full name of executable longer than 15 characters",
+			givenCmdline:       "/opt/ut/System/UnrealTournament.exe\x00-log\x00",
+			givenComm:          "UnrealTournamen",
+			expectedExecutable: "UnrealTournament.exe",
+		},
+		{
+			name:               "returns full name of executable started via Wine",
+			givenCmdline:       "C:\\UnrealTournament\\System\\UnrealTournament.exe\x00",
+			givenComm:          "UnrealTournamen",
+			expectedExecutable: "UnrealTournament.exe",
+		},
+		{
+			name:               "returns name of executable without path",
+			givenCmdline:       "bf2.exe\x00+restart\x001\x00",
+			givenComm:          "bf2.exe",
+			expectedExecutable: "bf2.exe",
+		},
+		{
+			name:               "returns command name if it was not truncated",
+			givenCmdline:       "postgres: checkpointer\x00",
+			givenComm:          "postgres",
+			expectedExecutable: "postgres",
+		},
+		{
+			name:               "returns command name for empty command line",
+			givenCmdline:       "",
+			givenComm:          "kworker/u16:0-e",
+			expectedExecutable: "kworker/u16:0-e",
+		},
+		{
+			name:               "returns command name if command line was overwritten",
+			givenCmdline:       "some-daemon: worker\x00",
+			givenComm:          "some-long-daemo",
+			expectedExecutable: "some-long-daemo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			executable := executableFromCmdline([]byte(tt.givenCmdline), tt.givenComm)
+
+			// THEN
+			assert.Equal(t, tt.expectedExecutable, executable)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package process_manager
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/mitchellh/go-ps"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	wmClose = 0x0010
+)
+
+var (
+	user32           = windows.NewLazySystemDLL("user32.dll")
+	procPostMessageW = user32.NewProc("PostMessageW")
+
+	// Callbacks cannot be released, so create a single one to use for every window enumeration
+	enumWindowsCallback = windows.NewCallback(collectProcessWindow)
+)
+
+type windowSearch struct {
+	pid     uint32
+	windows []windows.HWND
+}
+
+func collectProcessWindow(hwnd windows.HWND, param unsafe.Pointer) uintptr {
+	search := (*windowSearch)(param)
+	var pid uint32
+	if _, err := windows.GetWindowThreadProcessId(hwnd, &pid); err == nil && pid == search.pid {
+		search.windows = append(search.windows, hwnd)
+	}
+	// Continue enumeration
+	return 1
+}
+
+// Terminate Asks the process with the given pid to exit by sending WM_CLOSE to all of its top-level windows
+// (the same thing happens when clicking a window's close button)
+func (m *ProcessManager) Terminate(pid int) error {
+	search := &windowSearch{pid: uint32(pid)}
+	if err := windows.EnumWindows(enumWindowsCallback, unsafe.Pointer(search)); err != nil {
+		return fmt.Errorf("failed to enumerate windows: %s", err)
+	}
+
+	if len(search.windows) == 0 {
+		return fmt.Errorf("process does not have any windows to close: %d", pid)
+	}
+
+	for _, hwnd := range search.windows {
+		if r, _, err := procPostMessageW.Call(uintptr(hwnd), wmClose, 0, 0); r == 0 {
+			return fmt.Errorf("failed to send close message to window: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// executableName Returns the process' executable name (which is not truncated on Windows)
+func executableName(process ps.Process) string {
+	return process.Executable()
+}
@@ -41,6 +41,24 @@ func (r *RegistryRepository) GetStringValue(k registry.Key, path string, valueNa
 	return value, nil
 }
 
+func (r *RegistryRepository) GetSubKeyNames(k registry.Key, path string) ([]string, error) {
+	var names []string
+	err := r.OpenKey(k, path, registry.ENUMERATE_SUB_KEYS, func(key registry.Key) error {
+		var err error
+		names, err = key.ReadSubKeyNames(-1)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
 func (r *RegistryRepository) SetStringValue(k registry.Key, path string, valueName string, value string) error {
 	return r.OpenKey(k, path, registry.QUERY_VALUE|registry.SET_VALUE, func(key registry.Key) error {
 		return key.SetStringValue(valueName, value)
@@ -77,6 +77,48 @@ func TestRegistryRepository_GetStringValue(t *testing.T) {
 	})
 }
 
+func TestRegistryRepository_GetSubKeyNames(t *testing.T) {
+	registryRepository := New()
+	rand.Seed(time.Now().UnixNano())
+
+	t.Run("successfully retrieves sub key names", func(t *testing.T) {
+		// GIVEN
+		key := registry.CURRENT_USER
+		path := fmt.Sprintf("SOFTWARE\\some-test-key-%d", rand.Int()%512)
+		subKeyNames := []string{"some-sub-key", "some-other-sub-key"}
+		for _, name := range subKeyNames {
+			err := registryRepository.CreateKey(key, fmt.Sprintf("%s\\%s", path, name))
+			require.NoError(t, err)
+		}
+
+		t.Cleanup(func() {
+			for _, name := range subKeyNames {
+				_ = registryRepository.DeleteKey(key, fmt.Sprintf("%s\\%s", path, name))
+			}
+			_ = registryRepository.DeleteKey(key, path)
+		})
+
+		// WHEN
+		names, err := registryRepository.GetSubKeyNames(key, path)
+
+		// THEN
+		require.NoError(t, err)
+		assert.ElementsMatch(t, subKeyNames, names)
+	})
+
+	t.Run("error for non-existing path", func(t *testing.T) {
+		// GIVEN
+		key := registry.CURRENT_USER
+		path := "SOFTWARE\\this-does-not-exist"
+
+		// WHEN
+		_, err := registryRepository.GetSubKeyNames(key, path)
+
+		// THEN
+		require.ErrorIs(t, err, registry.ErrNotExist)
+	})
+}
+
 func TestRegistryRepository_DeleteValue(t *testing.T) {
 	registryRepository := New()
 	rand.Seed(time.Now().UnixNano())
@@ -0,0 +1,56 @@
+package server_query
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	gameSpy1StatusRequest = "\\status\\"
+	gameSpy1FinalKey      = "final"
+	gameSpy1QueryIDKey    = "queryid"
+)
+
+// queryGameSpy1 Sends a "\status\" request, which is answered with \key\value pairs. Large responses are split into
+// multiple packets, with the last one containing the (valueless) \final\ key.
+func queryGameSpy1(conn net.Conn) (*ServerInfo, error) {
+	if _, err := conn.Write([]byte(gameSpy1StatusRequest)); err != nil {
+		return nil, err
+	}
+
+	rules := map[string]string{}
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		response := string(buf[:n])
+		if !strings.HasPrefix(response, "\\") {
+			return nil, fmt.Errorf("GameSpy v1 response is not a list of key/value pairs")
+		}
+
+		final := strings.HasSuffix(response, "\\"+gameSpy1FinalKey+"\\")
+		response = strings.TrimSuffix(response, gameSpy1FinalKey+"\\")
+		for key, value := range parseInfoString(response) {
+			rules[key] = value
+		}
+
+		if final {
+			break
+		}
+	}
+	delete(rules, gameSpy1QueryIDKey)
+
+	return &ServerInfo{
+		Name:       rules["hostname"],
+		Map:        rules["mapname"],
+		GameType:   rules["gametype"],
+		NumPlayers: parseInt(rules["numplayers"]),
+		MaxPlayers: parseInt(rules["maxplayers"]),
+		Password:   parseBool(rules["password"]),
+		Rules:      rules,
+	}, nil
+}
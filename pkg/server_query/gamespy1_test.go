@@ -0,0 +1,103 @@
+//go:build unit
+
+package server_query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerQuery_Query_GameSpy1(t *testing.T) {
+	type test struct {
+		name            string
+		givenResponses  []string
+		expectedInfo    *ServerInfo
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name: "successfully queries server",
+			givenResponses: []string{
+				"\\hostname\\Some UT Server\\mapname\\DM-Deck16][\\gametype\\DeathMatchPlus\\numplayers\\3\\maxplayers\\16\\password\\True\\queryid\\12.1\\final\\",
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "Some UT Server",
+				Map:        "DM-Deck16][",
+				GameType:   "DeathMatchPlus",
+				NumPlayers: 3,
+				MaxPlayers: 16,
+				Password:   true,
+				Rules: map[string]string{
+					"hostname":   "Some UT Server",
+					"mapname":    "DM-Deck16][",
+					"gametype":   "DeathMatchPlus",
+					"numplayers": "3",
+					"maxplayers": "16",
+					"password":   "True",
+				},
+			},
+		},
+		{
+			name: "successfully queries server with response split into multiple packets",
+			givenResponses: []string{
+				"\\hostname\\Some UT Server\\gametype\\CTFGame\\queryid\\13.1",
+				"\\numplayers\\0\\maxplayers\\12\\password\\False\\queryid\\13.2\\final\\",
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "Some UT Server",
+				GameType:   "CTFGame",
+				MaxPlayers: 12,
+				Rules: map[string]string{
+					"hostname":   "Some UT Server",
+					"gametype":   "CTFGame",
+					"numplayers": "0",
+					"maxplayers": "12",
+					"password":   "False",
+				},
+			},
+		},
+		{
+			name:            "error for invalid response",
+			givenResponses:  []string{"not-a-key-value-list"},
+			wantErrContains: "GameSpy v1 response is not a list of key/value pairs",
+		},
+		{
+			name:            "error if final packet is missing",
+			givenResponses:  []string{"\\hostname\\Some UT Server\\queryid\\14.1"},
+			wantErrContains: "timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			requests := make(chan []byte, 10)
+			port := startUDPServer(t, func(request []byte) [][]byte {
+				requests <- request
+				packets := make([][]byte, 0, len(tt.givenResponses))
+				for _, response := range tt.givenResponses {
+					packets = append(packets, []byte(response))
+				}
+				return packets
+			})
+			query := New(200 * time.Millisecond)
+
+			// WHEN
+			info, err := query.Query(Config{Protocol: ProtocolGameSpy1, PortOffset: 1}, "127.0.0.1", port-1)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInfo, info)
+			}
+			require.Len(t, requests, 1)
+			assert.Equal(t, []byte("\\status\\"), <-requests)
+		})
+	}
+}
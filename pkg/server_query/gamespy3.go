@@ -0,0 +1,88 @@
+package server_query
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+)
+
+const (
+	gameSpy3PacketTypeInfo = 0x00
+	gameSpy3SplitNumHeader = "splitnum\x00"
+	gameSpy3LastPacketFlag = 0x80
+)
+
+var gameSpy3Magic = []byte{0xFE, 0xFD}
+
+// queryGameSpy3 Requests all server info, player info and team info, but only parses the server info, since it is
+// always sent first (any player/team info can span multiple packets, which are not needed here)
+func queryGameSpy3(conn net.Conn) (*ServerInfo, error) {
+	// Session id is echoed by the server, so any (fixed) value works
+	sessionID := []byte{0x10, 0x20, 0x30, 0x40}
+	request := append(append(append([]byte{}, gameSpy3Magic...), gameSpy3PacketTypeInfo), sessionID...)
+	request = append(request, 0xFF, 0xFF, 0xFF, 0x01)
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, index, err := parseGameSpy3Packet(buf[:n], sessionID)
+		if err != nil {
+			return nil, err
+		}
+		// Packets may arrive out of order, so skip any packets until we receive the one containing the server info
+		if index != 0 {
+			continue
+		}
+
+		return parseGameSpy3ServerInfo(payload), nil
+	}
+}
+
+// parseGameSpy3Packet Returns the payload and the index of the (split) packet
+func parseGameSpy3Packet(packet []byte, sessionID []byte) ([]byte, int, error) {
+	// Header: packet type (1 byte), session id (4 bytes), "splitnum\0", packet index (1 byte), unknown (1 byte)
+	headerLength := 5 + len(gameSpy3SplitNumHeader) + 2
+	if len(packet) < headerLength {
+		return nil, 0, fmt.Errorf("GameSpy v3 response is too short: %d bytes", len(packet))
+	}
+	if packet[0] != gameSpy3PacketTypeInfo || !bytes.Equal(packet[1:5], sessionID) {
+		return nil, 0, fmt.Errorf("GameSpy v3 response does not match request")
+	}
+	if !bytes.Equal(packet[5:5+len(gameSpy3SplitNumHeader)], []byte(gameSpy3SplitNumHeader)) {
+		return nil, 0, fmt.Errorf("GameSpy v3 response is missing split header")
+	}
+
+	index := int(packet[5+len(gameSpy3SplitNumHeader)] &^ gameSpy3LastPacketFlag)
+	return packet[headerLength:], index, nil
+}
+
+// parseGameSpy3ServerInfo Parses the null-terminated key/value pairs of the server info (ending with an empty key)
+func parseGameSpy3ServerInfo(payload []byte) *ServerInfo {
+	rules := map[string]string{}
+	fields := bytes.Split(payload, []byte{0x00})
+	for i := 0; i+1 < len(fields); i += 2 {
+		key := string(fields[i])
+		if key == "" {
+			break
+		}
+		rules[key] = string(fields[i+1])
+	}
+
+	return &ServerInfo{
+		Name:       rules["hostname"],
+		Map:        rules["mapname"],
+		GameType:   rules["gametype"],
+		Mod:        rules["gamevariant"],
+		NumPlayers: parseInt(rules["numplayers"]),
+		MaxPlayers: parseInt(rules["maxplayers"]),
+		Password:   parseBool(rules["password"]),
+		Rules:      rules,
+	}
+}
@@ -0,0 +1,172 @@
+//go:build unit
+
+package server_query
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerQuery_Query_GameSpy3(t *testing.T) {
+	type test struct {
+		name            string
+		givenPortOffset int
+		respond         func(request []byte) [][]byte
+		expectedInfo    *ServerInfo
+		wantErrContains string
+	}
+
+	serverInfo := buildGameSpy3Payload(
+		"hostname", "=DOG= No Explosives (Inf)",
+		"gamename", "battlefield2",
+		"mapname", "Strike At Karkand",
+		"gametype", "gpm_cq",
+		"gamevariant", "bf2",
+		"numplayers", "63",
+		"maxplayers", "64",
+		"password", "0",
+	)
+	playerInfo := []byte("\x01player_\x00\x00some-player\x00\x00")
+
+	tests := []test{
+		{
+			name: "successfully queries server",
+			respond: func(request []byte) [][]byte {
+				return [][]byte{
+					buildGameSpy3Packet(request, 0x00, serverInfo),
+					buildGameSpy3Packet(request, 0x81, playerInfo),
+				}
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "=DOG= No Explosives (Inf)",
+				Map:        "Strike At Karkand",
+				GameType:   "gpm_cq",
+				Mod:        "bf2",
+				NumPlayers: 63,
+				MaxPlayers: 64,
+				Password:   false,
+				Rules: map[string]string{
+					"hostname":    "=DOG= No Explosives (Inf)",
+					"gamename":    "battlefield2",
+					"mapname":     "Strike At Karkand",
+					"gametype":    "gpm_cq",
+					"gamevariant": "bf2",
+					"numplayers":  "63",
+					"maxplayers":  "64",
+					"password":    "0",
+				},
+			},
+		},
+		{
+			name: "successfully queries server with packets arriving out of order",
+			respond: func(request []byte) [][]byte {
+				return [][]byte{
+					buildGameSpy3Packet(request, 0x81, playerInfo),
+					buildGameSpy3Packet(request, 0x00, buildGameSpy3Payload(
+						"hostname", "some-server",
+						"gamevariant", "xpack",
+						"numplayers", "64",
+						"maxplayers", "64",
+						"password", "1",
+					)),
+				}
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "some-server",
+				Mod:        "xpack",
+				NumPlayers: 64,
+				MaxPlayers: 64,
+				Password:   true,
+				Rules: map[string]string{
+					"hostname":    "some-server",
+					"gamevariant": "xpack",
+					"numplayers":  "64",
+					"maxplayers":  "64",
+					"password":    "1",
+				},
+			},
+		},
+		{
+			name:            "successfully queries server via query port offset",
+			givenPortOffset: 100,
+			respond: func(request []byte) [][]byte {
+				return [][]byte{buildGameSpy3Packet(request, 0x80, buildGameSpy3Payload("hostname", "some-server"))}
+			},
+			expectedInfo: &ServerInfo{
+				Name:  "some-server",
+				Rules: map[string]string{"hostname": "some-server"},
+			},
+		},
+		{
+			name: "error for response with different session id",
+			respond: func(request []byte) [][]byte {
+				packet := buildGameSpy3Packet(request, 0x80, serverInfo)
+				packet[1] ^= 0xFF
+				return [][]byte{packet}
+			},
+			wantErrContains: "GameSpy v3 response does not match request",
+		},
+		{
+			name: "error for truncated response",
+			respond: func(request []byte) [][]byte {
+				return [][]byte{{0x00, 0x10}}
+			},
+			wantErrContains: "GameSpy v3 response is too short",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			requests := make(chan []byte, 10)
+			port := startUDPServer(t, func(request []byte) [][]byte {
+				requests <- request
+				return tt.respond(request)
+			})
+			query := New(time.Second)
+
+			// WHEN
+			info, err := query.Query(Config{Protocol: ProtocolGameSpy3, PortOffset: tt.givenPortOffset}, "127.0.0.1", port-tt.givenPortOffset)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInfo, info)
+			}
+			require.Len(t, requests, 1)
+			request := <-requests
+			assert.Equal(t, []byte{0xFE, 0xFD, 0x00}, request[:3])
+			assert.Equal(t, []byte{0xFF, 0xFF, 0xFF, 0x01}, request[7:])
+		})
+	}
+}
+
+// buildGameSpy3Packet Builds a response packet to the given request (reusing the session id) with the given packet
+// index (including the last packet flag)
+func buildGameSpy3Packet(request []byte, index byte, payload []byte) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(0x00)
+	buf.Write(request[3:7])
+	buf.WriteString("splitnum\x00")
+	buf.WriteByte(index)
+	buf.WriteByte(0x00)
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+func buildGameSpy3Payload(pairs ...string) []byte {
+	buf := new(bytes.Buffer)
+	for _, elem := range pairs {
+		buf.WriteString(elem)
+		buf.WriteByte(0x00)
+	}
+	// Server info ends with an empty key
+	buf.WriteByte(0x00)
+	return buf.Bytes()
+}
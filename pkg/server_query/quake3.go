@@ -0,0 +1,72 @@
+package server_query
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	quake3StatusResponseHeader = "statusResponse"
+)
+
+var (
+	quake3OutOfBandPrefix = []byte{0xFF, 0xFF, 0xFF, 0xFF}
+	// Color codes (e.g. ^1) used in server and player names
+	quake3ColorCodeRegex = regexp.MustCompile(`\^[0-9]`)
+)
+
+// queryQuake3 Sends an out-of-band "getstatus" request, which is answered with the server's info string
+// (\key\value pairs), followed by one line per player
+func queryQuake3(conn net.Conn) (*ServerInfo, error) {
+	request := append(append([]byte{}, quake3OutOfBandPrefix...), []byte("getstatus")...)
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxPacketSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseQuake3StatusResponse(buf[:n])
+}
+
+func parseQuake3StatusResponse(packet []byte) (*ServerInfo, error) {
+	if !bytes.HasPrefix(packet, quake3OutOfBandPrefix) {
+		return nil, fmt.Errorf("Quake 3 response is missing out-of-band prefix")
+	}
+
+	lines := strings.Split(strings.TrimRight(string(packet[len(quake3OutOfBandPrefix):]), "\n"), "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[0]) != quake3StatusResponseHeader {
+		return nil, fmt.Errorf("Quake 3 response is not a status response")
+	}
+
+	rules := parseInfoString(lines[1])
+	// Password flag was renamed over time, with Call of Duty titles using pswrd rather than g_needpass
+	password := parseBool(rules["pswrd"]) || parseBool(rules["g_needpass"])
+
+	return &ServerInfo{
+		Name:       quake3ColorCodeRegex.ReplaceAllString(rules["sv_hostname"], ""),
+		Map:        rules["mapname"],
+		GameType:   rules["g_gametype"],
+		Mod:        rules["fs_game"],
+		NumPlayers: len(lines) - 2,
+		MaxPlayers: parseInt(rules["sv_maxclients"]),
+		Password:   password,
+		Rules:      rules,
+	}, nil
+}
+
+// parseInfoString Parses backslash separated key/value pairs (\key1\value1\key2\value2)
+func parseInfoString(info string) map[string]string {
+	rules := map[string]string{}
+	elems := strings.Split(strings.TrimPrefix(info, "\\"), "\\")
+	for i := 0; i+1 < len(elems); i += 2 {
+		rules[elems[i]] = elems[i+1]
+	}
+	return rules
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+package server_query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerQuery_Query_Quake3(t *testing.T) {
+	type test struct {
+		name            string
+		givenResponse   string
+		expectedInfo    *ServerInfo
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name: "successfully queries server",
+			givenResponse: "\xff\xff\xff\xffstatusResponse\n" +
+				"\\sv_hostname\\^1Some ^7Server\\mapname\\mp_crash\\g_gametype\\war\\fs_game\\mods/pml220\\sv_maxclients\\24\\pswrd\\1\n" +
+				"10 50 \"some-player\"\n" +
+				"0 999 \"^2other-player\"\n",
+			expectedInfo: &ServerInfo{
+				Name:       "Some Server",
+				Map:        "mp_crash",
+				GameType:   "war",
+				Mod:        "mods/pml220",
+				NumPlayers: 2,
+				MaxPlayers: 24,
+				Password:   true,
+				Rules: map[string]string{
+					"sv_hostname":   "^1Some ^7Server",
+					"mapname":       "mp_crash",
+					"g_gametype":    "war",
+					"fs_game":       "mods/pml220",
+					"sv_maxclients": "24",
+					"pswrd":         "1",
+				},
+			},
+		},
+		{
+			name:          "successfully queries empty server without mod",
+			givenResponse: "\xff\xff\xff\xffstatusResponse\n\\sv_hostname\\some-server\\mapname\\mp_carentan\\sv_maxclients\\32\\g_needpass\\0\n",
+			expectedInfo: &ServerInfo{
+				Name:       "some-server",
+				Map:        "mp_carentan",
+				MaxPlayers: 32,
+				Rules: map[string]string{
+					"sv_hostname":   "some-server",
+					"mapname":       "mp_carentan",
+					"sv_maxclients": "32",
+					"g_needpass":    "0",
+				},
+			},
+		},
+		{
+			name:            "error for response without out-of-band prefix",
+			givenResponse:   "statusResponse\n\\sv_hostname\\some-server\n",
+			wantErrContains: "Quake 3 response is missing out-of-band prefix",
+		},
+		{
+			name:            "error for response other than status response",
+			givenResponse:   "\xff\xff\xff\xffdisconnect",
+			wantErrContains: "Quake 3 response is not a status response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			requests := make(chan []byte, 10)
+			port := startUDPServer(t, func(request []byte) [][]byte {
+				requests <- request
+				return [][]byte{[]byte(tt.givenResponse)}
+			})
+			query := New(time.Second)
+
+			// WHEN
+			info, err := query.Query(Config{Protocol: ProtocolQuake3}, "127.0.0.1", port)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInfo, info)
+			}
+			require.Len(t, requests, 1)
+			assert.Equal(t, []byte("\xff\xff\xff\xffgetstatus"), <-requests)
+		})
+	}
+}
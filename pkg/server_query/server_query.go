@@ -0,0 +1,104 @@
+package server_query
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+type Protocol string
+
+const (
+	// ProtocolGameSpy1 GameSpy v1 "\status\" query protocol, as used by Unreal Engine 1 titles and Battlefield 1942/Vietnam
+	ProtocolGameSpy1 Protocol = "gamespy1"
+	// ProtocolGameSpy3 GameSpy v3 (aka GameSpy 4) query protocol without challenge, as used by Battlefield 2
+	ProtocolGameSpy3 Protocol = "gamespy3"
+	// ProtocolQuake3 Quake 3 "getstatus" query protocol, as used by Call of Duty titles
+	ProtocolQuake3 Protocol = "quake3"
+	// ProtocolUnreal2 Native Unreal Engine 2 query protocol, as used by Unreal Tournament 2004
+	ProtocolUnreal2 Protocol = "unreal2"
+
+	maxPacketSize = 65535
+)
+
+type Config struct {
+	Protocol Protocol
+	// Offset from the game port to the query port (e.g. 13333 for Battlefield 2's default ports 16567 and 29900)
+	PortOffset int
+}
+
+type ServerInfo struct {
+	Name       string
+	Map        string
+	GameType   string
+	Mod        string
+	NumPlayers int
+	MaxPlayers int
+	Password   bool
+	// Any key/value pairs the server reported, as returned by the server
+	Rules map[string]string
+}
+
+func (i *ServerInfo) IsFull() bool {
+	return i.MaxPlayers > 0 && i.NumPlayers >= i.MaxPlayers
+}
+
+type ServerQuery struct {
+	timeout time.Duration
+}
+
+func New(timeout time.Duration) *ServerQuery {
+	return &ServerQuery{
+		timeout: timeout,
+	}
+}
+
+// Query Queries the server running on the given host and game port according to the given config
+func (q *ServerQuery) Query(config Config, host string, gamePort int) (*ServerInfo, error) {
+	queryPort := gamePort + config.PortOffset
+	if queryPort <= 0 || queryPort > 65535 {
+		return nil, fmt.Errorf("invalid query port: %d", queryPort)
+	}
+
+	conn, err := net.Dial("udp", net.JoinHostPort(host, strconv.Itoa(queryPort)))
+	if err != nil {
+		return nil, err
+	}
+	defer func(conn net.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	deadline := time.Now().Add(q.timeout)
+	if err = conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	switch config.Protocol {
+	case ProtocolGameSpy1:
+		return queryGameSpy1(conn)
+	case ProtocolGameSpy3:
+		return queryGameSpy3(conn)
+	case ProtocolQuake3:
+		return queryQuake3(conn)
+	case ProtocolUnreal2:
+		return queryUnreal2(conn, deadline)
+	default:
+		return nil, fmt.Errorf("unsupported query protocol: %s", config.Protocol)
+	}
+}
+
+// parseBool Parses the different ways servers report boolean values (0/1, true/false)
+func parseBool(value string) bool {
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
+
+// parseInt Parses an integer value, treating any invalid value as zero
+func parseInt(value string) int {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return i
+}
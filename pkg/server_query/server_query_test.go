@@ -0,0 +1,83 @@
+//go:build unit
+
+package server_query
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerQuery_Query(t *testing.T) {
+	t.Run("error for unsupported protocol", func(t *testing.T) {
+		// GIVEN
+		port := startUDPServer(t, func(request []byte) [][]byte { return nil })
+		query := New(time.Second)
+
+		// WHEN
+		_, err := query.Query(Config{Protocol: "not-a-protocol"}, "127.0.0.1", port)
+
+		// THEN
+		require.ErrorContains(t, err, "unsupported query protocol: not-a-protocol")
+	})
+
+	t.Run("error for invalid query port", func(t *testing.T) {
+		// GIVEN
+		query := New(time.Second)
+
+		// WHEN
+		_, err := query.Query(Config{Protocol: ProtocolGameSpy3, PortOffset: 13333}, "127.0.0.1", 60000)
+
+		// THEN
+		require.ErrorContains(t, err, "invalid query port: 73333")
+	})
+
+	t.Run("error if server does not respond", func(t *testing.T) {
+		// GIVEN
+		port := startUDPServer(t, func(request []byte) [][]byte { return nil })
+		query := New(100 * time.Millisecond)
+
+		// WHEN
+		_, err := query.Query(Config{Protocol: ProtocolGameSpy3}, "127.0.0.1", port)
+
+		// THEN
+		require.ErrorContains(t, err, "timeout")
+	})
+}
+
+func TestServerInfo_IsFull(t *testing.T) {
+	assert.True(t, (&ServerInfo{NumPlayers: 64, MaxPlayers: 64}).IsFull())
+	assert.False(t, (&ServerInfo{NumPlayers: 63, MaxPlayers: 64}).IsFull())
+	// Servers not reporting max players cannot be full
+	assert.False(t, (&ServerInfo{NumPlayers: 1}).IsFull())
+}
+
+// startUDPServer Starts a local UDP server answering every request with the packets returned by the given handler,
+// returning the port the server listens on
+func startUDPServer(t *testing.T, handler func(request []byte) [][]byte) int {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, maxPacketSize)
+		for {
+			n, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			for _, packet := range handler(append([]byte{}, buf[:n]...)) {
+				_, _ = conn.WriteToUDP(packet, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
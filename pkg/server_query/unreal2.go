@@ -0,0 +1,159 @@
+package server_query
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	unreal2QueryTypeInfo  = 0x00
+	unreal2QueryTypeRules = 0x01
+	// Strings with a length byte with this bit set are UCS-2 encoded, with the remaining bits being the character count
+	unreal2StringUCS2Flag = 0x80
+	// Time to wait for the rules once the info has arrived (servers send both right away, so any longer wait means the
+	// rules got lost or the server does not answer rules requests)
+	unreal2RulesTimeout = 250 * time.Millisecond
+)
+
+var (
+	unreal2Header = []byte{0x80, 0x00, 0x00, 0x00}
+	// Color codes (escape character followed by RGB values) plus any other control characters used in names
+	unreal2ColorCodeRegex = regexp.MustCompile("\x1b...|[\x00-\x1a]")
+)
+
+// queryUnreal2 Queries server info and rules via the native Unreal Engine 2 query protocol (UT2003/UT2004). Since only
+// the rules contain the password flag, both are requested. Missing rules are not considered an error, though.
+func queryUnreal2(conn net.Conn, deadline time.Time) (*ServerInfo, error) {
+	for _, queryType := range []byte{unreal2QueryTypeInfo, unreal2QueryTypeRules} {
+		if _, err := conn.Write(append(append([]byte{}, unreal2Header...), queryType)); err != nil {
+			return nil, err
+		}
+	}
+
+	var info *ServerInfo
+	var rules map[string]string
+	buf := make([]byte, maxPacketSize)
+	for info == nil || rules == nil {
+		n, err := conn.Read(buf)
+		if err != nil {
+			if info != nil && errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			return nil, err
+		}
+
+		packet := buf[:n]
+		if len(packet) < len(unreal2Header)+1 {
+			return nil, fmt.Errorf("Unreal 2 response is too short: %d bytes", len(packet))
+		}
+
+		reader := bytes.NewReader(packet[len(unreal2Header)+1:])
+		switch packet[len(unreal2Header)] {
+		case unreal2QueryTypeInfo:
+			if info, err = parseUnreal2Info(reader); err != nil {
+				return nil, err
+			}
+			// Do not wait the full timeout for rules which may never arrive
+			if rulesDeadline := time.Now().Add(unreal2RulesTimeout); rules == nil && rulesDeadline.Before(deadline) {
+				if err = conn.SetReadDeadline(rulesDeadline); err != nil {
+					return nil, err
+				}
+			}
+		case unreal2QueryTypeRules:
+			if rules, err = parseUnreal2Rules(reader); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected Unreal 2 response type: %d", packet[len(unreal2Header)])
+		}
+	}
+
+	info.Rules = rules
+	if info.Rules == nil {
+		info.Rules = map[string]string{}
+	}
+	info.Password = parseBool(info.Rules["GamePassword"])
+
+	return info, nil
+}
+
+func parseUnreal2Info(reader *bytes.Reader) (*ServerInfo, error) {
+	var serverID, gamePort, queryPort int32
+	info := &ServerInfo{}
+	var numPlayers, maxPlayers int32
+
+	// Server id, ip, game port, query port, name, map, game type, number of players, max players (followed by
+	// more details we do not need)
+	fields := []interface{}{&serverID, new(string), &gamePort, &queryPort, &info.Name, &info.Map, &info.GameType, &numPlayers, &maxPlayers}
+	for _, field := range fields {
+		var err error
+		switch v := field.(type) {
+		case *string:
+			*v, err = readUnreal2String(reader)
+		default:
+			err = binary.Read(reader, binary.LittleEndian, v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Unreal 2 server info: %s", err)
+		}
+	}
+
+	info.NumPlayers = int(numPlayers)
+	info.MaxPlayers = int(maxPlayers)
+
+	return info, nil
+}
+
+func parseUnreal2Rules(reader *bytes.Reader) (map[string]string, error) {
+	rules := map[string]string{}
+	for reader.Len() > 0 {
+		key, err := readUnreal2String(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Unreal 2 rules: %s", err)
+		}
+		value, err := readUnreal2String(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Unreal 2 rules: %s", err)
+		}
+		rules[key] = value
+	}
+	return rules, nil
+}
+
+// readUnreal2String Reads a length-prefixed, null-terminated string, stripping any color codes and control characters
+func readUnreal2String(reader *bytes.Reader) (string, error) {
+	length, err := reader.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+	if length&unreal2StringUCS2Flag != 0 {
+		chars := make([]uint16, length&^unreal2StringUCS2Flag)
+		if err = binary.Read(reader, binary.LittleEndian, chars); err != nil {
+			return "", err
+		}
+		s = string(utf16.Decode(chars))
+	} else {
+		b := make([]byte, length)
+		if _, err = io.ReadFull(reader, b); err != nil {
+			return "", err
+		}
+		// Non-UCS-2 strings are Latin-1 encoded, which maps directly to the first 256 Unicode code points
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		s = string(runes)
+	}
+
+	return unreal2ColorCodeRegex.ReplaceAllString(s, ""), nil
+}
@@ -0,0 +1,208 @@
+//go:build unit
+
+package server_query
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerQuery_Query_Unreal2(t *testing.T) {
+	type test struct {
+		name            string
+		respond         func(request []byte) [][]byte
+		expectedInfo    *ServerInfo
+		wantErrContains string
+	}
+
+	info := buildUnreal2InfoPayload(unreal2String("\x1b\xff\x00\x00Some \x1b\xff\xff\xffONS Server\x00"), "ONS-Torlan\x00", "ONSOnslaughtGame\x00", 12, 32)
+	rules := buildUnreal2Payload(unreal2QueryTypeRules, "AdminName\x00", "some-admin\x00", "GamePassword\x00", "True\x00")
+
+	tests := []test{
+		{
+			name: "successfully queries server",
+			respond: func(request []byte) [][]byte {
+				switch request[4] {
+				case unreal2QueryTypeInfo:
+					return [][]byte{info}
+				default:
+					return [][]byte{rules}
+				}
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "Some ONS Server",
+				Map:        "ONS-Torlan",
+				GameType:   "ONSOnslaughtGame",
+				NumPlayers: 12,
+				MaxPlayers: 32,
+				Password:   true,
+				Rules: map[string]string{
+					"AdminName":    "some-admin",
+					"GamePassword": "True",
+				},
+			},
+		},
+		{
+			name: "successfully queries server with UCS-2 encoded name",
+			respond: func(request []byte) [][]byte {
+				if request[4] == unreal2QueryTypeInfo {
+					return [][]byte{buildUnreal2InfoPayload(unreal2UCS2String("Sérvér\x00"), "DM-Rankin\x00", "xDeathMatch\x00", 0, 16)}
+				}
+				return [][]byte{buildUnreal2Payload(unreal2QueryTypeRules, "GamePassword\x00", "False\x00", "ServerMode\x00", "dedicated\x00")}
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "Sérvér",
+				Map:        "DM-Rankin",
+				GameType:   "xDeathMatch",
+				MaxPlayers: 16,
+				Rules: map[string]string{
+					"GamePassword": "False",
+					"ServerMode":   "dedicated",
+				},
+			},
+		},
+		{
+			name: "successfully queries server with Latin-1 encoded name",
+			respond: func(request []byte) [][]byte {
+				if request[4] == unreal2QueryTypeInfo {
+					return [][]byte{buildUnreal2InfoPayload(unreal2String("S\xe9rv\xe9r\x00"), "DM-Rankin\x00", "xDeathMatch\x00", 1, 16)}
+				}
+				return [][]byte{buildUnreal2Payload(unreal2QueryTypeRules)}
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "Sérvér",
+				Map:        "DM-Rankin",
+				GameType:   "xDeathMatch",
+				NumPlayers: 1,
+				MaxPlayers: 16,
+				Rules:      map[string]string{},
+			},
+		},
+		{
+			name: "successfully queries server not answering rules request",
+			respond: func(request []byte) [][]byte {
+				if request[4] == unreal2QueryTypeInfo {
+					return [][]byte{info}
+				}
+				return nil
+			},
+			expectedInfo: &ServerInfo{
+				Name:       "Some ONS Server",
+				Map:        "ONS-Torlan",
+				GameType:   "ONSOnslaughtGame",
+				NumPlayers: 12,
+				MaxPlayers: 32,
+				Rules:      map[string]string{},
+			},
+		},
+		{
+			name: "error for truncated server info",
+			respond: func(request []byte) [][]byte {
+				if request[4] == unreal2QueryTypeInfo {
+					return [][]byte{info[:20]}
+				}
+				return nil
+			},
+			wantErrContains: "failed to parse Unreal 2 server info",
+		},
+		{
+			name: "error if server does not answer info request",
+			respond: func(request []byte) [][]byte {
+				if request[4] == unreal2QueryTypeRules {
+					return [][]byte{rules}
+				}
+				return nil
+			},
+			wantErrContains: "timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			requests := make(chan []byte, 10)
+			port := startUDPServer(t, func(request []byte) [][]byte {
+				requests <- request
+				return tt.respond(request)
+			})
+			query := New(200 * time.Millisecond)
+
+			// WHEN
+			result, err := query.Query(Config{Protocol: ProtocolUnreal2, PortOffset: 1}, "127.0.0.1", port-1)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInfo, result)
+			}
+			require.Len(t, requests, 2)
+			assert.Equal(t, []byte{0x80, 0x00, 0x00, 0x00, 0x00}, <-requests)
+			assert.Equal(t, []byte{0x80, 0x00, 0x00, 0x00, 0x01}, <-requests)
+		})
+	}
+}
+
+func TestServerQuery_Query_Unreal2_RulesTimeout(t *testing.T) {
+	// GIVEN
+	info := buildUnreal2InfoPayload(unreal2String("Some ONS Server\x00"), "ONS-Torlan\x00", "ONSOnslaughtGame\x00", 12, 32)
+	port := startUDPServer(t, func(request []byte) [][]byte {
+		if request[4] == unreal2QueryTypeInfo {
+			return [][]byte{info}
+		}
+		return nil
+	})
+	query := New(5 * time.Second)
+
+	// WHEN
+	start := time.Now()
+	result, err := query.Query(Config{Protocol: ProtocolUnreal2, PortOffset: 1}, "127.0.0.1", port-1)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, "Some ONS Server", result.Name)
+	assert.Equal(t, map[string]string{}, result.Rules)
+	// Should only wait for the rules until the secondary deadline, not until the (much longer) timeout
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func buildUnreal2InfoPayload(name []byte, mapName string, gameType string, numPlayers int32, maxPlayers int32) []byte {
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, int32(1))
+	buf.Write(unreal2String("127.0.0.1\x00"))
+	_ = binary.Write(buf, binary.LittleEndian, []int32{7777, 7778})
+	buf.Write(name)
+	buf.Write(unreal2String(mapName))
+	buf.Write(unreal2String(gameType))
+	_ = binary.Write(buf, binary.LittleEndian, []int32{numPlayers, maxPlayers, 0, 0})
+	return append(append([]byte{0x80, 0x00, 0x00, 0x00}, unreal2QueryTypeInfo), buf.Bytes()...)
+}
+
+func buildUnreal2Payload(queryType byte, strs ...string) []byte {
+	buf := new(bytes.Buffer)
+	for _, s := range strs {
+		buf.Write(unreal2String(s))
+	}
+	return append(append([]byte{0x80, 0x00, 0x00, 0x00}, queryType), buf.Bytes()...)
+}
+
+// unreal2String Encodes a string as length-prefixed ASCII string
+func unreal2String(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+// unreal2UCS2String Encodes a string as length-prefixed UCS-2 string
+func unreal2UCS2String(s string) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(byte(len([]rune(s))) | unreal2StringUCS2Flag)
+	for _, r := range s {
+		_ = binary.Write(buf, binary.LittleEndian, uint16(r))
+	}
+	return buf.Bytes()
+}
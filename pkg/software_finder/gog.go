@@ -0,0 +1,109 @@
+package software_finder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	gogRegistryPathTemplate = "SOFTWARE\\WOW6432Node\\GOG.com\\Games\\%s"
+	gogRegistryValueName    = "PATH"
+	gogInfoFilePattern      = "goggame-*.info"
+)
+
+type gogGameInfo struct {
+	GameID     string `json:"gameId"`
+	RootGameID string `json:"rootGameId"`
+}
+
+// MakeGOGLibraryConfigs Derives a GOGFinder config for every GOGFinder config and library path, so games can also be
+// found in the given library paths (e.g. portable or Linux installs, which are not listed in the registry)
+func MakeGOGLibraryConfigs(configs []Config, libraryPaths ...string) []Config {
+	libraryConfigs := make([]Config, 0, len(configs)*len(libraryPaths))
+	for _, libraryPath := range libraryPaths {
+		for _, config := range configs {
+			if config.ForType != GOGFinder || config.InstallPath != "" {
+				continue
+			}
+			// Config is not a pointer, so we can change "it" without affecting the original
+			config.InstallPath = libraryPath
+			libraryConfigs = append(libraryConfigs, config)
+		}
+	}
+	return libraryConfigs
+}
+
+func (f *SoftwareFinder) isInstalledAccordingToGOG(config Config) (bool, error) {
+	_, err := f.getInstallDirFromGOG(config)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || isRegistryNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// getInstallDirFromGOG Looks up the game in the GOG registry tree, unless a library path is given as install path
+func (f *SoftwareFinder) getInstallDirFromGOG(config Config) (string, error) {
+	if config.GOGGameID == "" {
+		return "", fmt.Errorf("GOG game id is missing from config")
+	}
+
+	if config.InstallPath != "" {
+		return f.getInstallDirFromGOGLibrary(config.InstallPath, config.GOGGameID)
+	}
+
+	return f.getInstallDirFromRegistry(Config{
+		RegistryKey:       RegistryKeyLocalMachine,
+		RegistryPath:      fmt.Sprintf(gogRegistryPathTemplate, config.GOGGameID),
+		RegistryValueName: gogRegistryValueName,
+	})
+}
+
+// getInstallDirFromGOGLibrary Searches the library path itself and any direct subdirectories for goggame-*.info files
+// belonging to the game. Since DLCs come with their own info file (referencing the base game as root game),
+// any file with a matching root game id identifies the install dir.
+func (f *SoftwareFinder) getInstallDirFromGOGLibrary(libraryPath string, gameID string) (string, error) {
+	patterns := []string{
+		filepath.Join(libraryPath, gogInfoFilePattern),
+		filepath.Join(libraryPath, "*", gogInfoFilePattern),
+	}
+
+	for _, pattern := range patterns {
+		matches, err := f.fileRepository.Glob(pattern)
+		if err != nil {
+			return "", err
+		}
+
+		for _, match := range matches {
+			content, err := f.fileRepository.ReadFile(match)
+			if err != nil {
+				return "", err
+			}
+
+			var info gogGameInfo
+			// Ignore any invalid info files, since they may well belong to a different game
+			if err = json.Unmarshal(content, &info); err != nil {
+				continue
+			}
+
+			rootGameID := info.RootGameID
+			if rootGameID == "" {
+				// Older info files do not contain a root game id
+				rootGameID = info.GameID
+			}
+
+			if rootGameID == gameID {
+				return filepath.Dir(match), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("GOG game is not installed in library %s: %s: %w", libraryPath, gameID, os.ErrNotExist)
+}
@@ -0,0 +1,155 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSoftwareFinder_GetInstallDir_GOGFinderWithLibrary(t *testing.T) {
+	type test struct {
+		name               string
+		givenConfig        Config
+		expect             func(fr *MockFileRepository)
+		expectedInstallDir string
+		wantErrContains    string
+	}
+
+	library := filepath.FromSlash("/library")
+	tests := []test{
+		{
+			name: "successfully determines install dir via info file in library subdir",
+			givenConfig: Config{
+				ForType:     GOGFinder,
+				GOGGameID:   "1409964317",
+				InstallPath: library,
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(filepath.Join(library, "goggame-*.info")).Return(nil, nil)
+				fr.EXPECT().Glob(filepath.Join(library, "*", "goggame-*.info")).Return([]string{
+					filepath.Join(library, "Other Game", "goggame-1111.info"),
+					filepath.Join(library, "SWAT 4", "goggame-1409964317.info"),
+				}, nil)
+				fr.EXPECT().ReadFile(filepath.Join(library, "Other Game", "goggame-1111.info")).Return([]byte(`{"gameId":"1111","rootGameId":"1111"}`), nil)
+				fr.EXPECT().ReadFile(filepath.Join(library, "SWAT 4", "goggame-1409964317.info")).Return([]byte(`{"gameId":"1409964317","rootGameId":"1409964317","name":"SWAT 4 Gold"}`), nil)
+				fr.EXPECT().DirExists(filepath.Join(library, "SWAT 4")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join(library, "SWAT 4"),
+		},
+		{
+			name: "successfully determines install dir via DLC info file referencing root game",
+			givenConfig: Config{
+				ForType:     GOGFinder,
+				GOGGameID:   "1409964317",
+				InstallPath: library,
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(filepath.Join(library, "goggame-*.info")).Return([]string{
+					filepath.Join(library, "goggame-2222.info"),
+				}, nil)
+				fr.EXPECT().ReadFile(filepath.Join(library, "goggame-2222.info")).Return([]byte(`{"gameId":"2222","rootGameId":"1409964317"}`), nil)
+				fr.EXPECT().DirExists(library).Return(true, nil)
+			},
+			expectedInstallDir: library,
+		},
+		{
+			name: "successfully determines install dir via info file without root game id",
+			givenConfig: Config{
+				ForType:     GOGFinder,
+				GOGGameID:   "1409964317",
+				InstallPath: library,
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(filepath.Join(library, "goggame-*.info")).Return(nil, nil)
+				fr.EXPECT().Glob(filepath.Join(library, "*", "goggame-*.info")).Return([]string{
+					filepath.Join(library, "Broken", "goggame-3333.info"),
+					filepath.Join(library, "SWAT 4", "goggame-1409964317.info"),
+				}, nil)
+				fr.EXPECT().ReadFile(filepath.Join(library, "Broken", "goggame-3333.info")).Return([]byte(`not-json`), nil)
+				fr.EXPECT().ReadFile(filepath.Join(library, "SWAT 4", "goggame-1409964317.info")).Return([]byte(`{"gameId":"1409964317"}`), nil)
+				fr.EXPECT().DirExists(filepath.Join(library, "SWAT 4")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join(library, "SWAT 4"),
+		},
+		{
+			name: "errors if game is not in library",
+			givenConfig: Config{
+				ForType:     GOGFinder,
+				GOGGameID:   "1409964317",
+				InstallPath: library,
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().Glob(gomock.Any()).Return(nil, nil).Times(2)
+			},
+			wantErrContains: "GOG game is not installed in library",
+		},
+		{
+			name: "errors for missing game id",
+			givenConfig: Config{
+				ForType:     GOGFinder,
+				InstallPath: library,
+			},
+			expect:          func(fr *MockFileRepository) {},
+			wantErrContains: "GOG game id is missing from config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(NewMockRegistryRepository(ctrl), mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.GetInstallDir(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInstallDir, installDir)
+			}
+		})
+	}
+}
+
+func TestMakeGOGLibraryConfigs(t *testing.T) {
+	// GIVEN
+	gogConfig := Config{
+		ForType:   GOGFinder,
+		GOGGameID: "1234",
+	}
+	libraryConfig := Config{
+		ForType:     GOGFinder,
+		GOGGameID:   "1234",
+		InstallPath: "/existing/library",
+	}
+	registryConfig := Config{
+		ForType:           RegistryFinder,
+		RegistryKey:       RegistryKeyLocalMachine,
+		RegistryPath:      "SOFTWARE\\some\\game",
+		RegistryValueName: "InstallDir",
+	}
+
+	// WHEN
+	configs := MakeGOGLibraryConfigs([]Config{gogConfig, libraryConfig, registryConfig}, "/library")
+
+	// THEN
+	assert.Equal(t, []Config{
+		{
+			ForType:     GOGFinder,
+			GOGGameID:   "1234",
+			InstallPath: "/library",
+		},
+	}, configs)
+}
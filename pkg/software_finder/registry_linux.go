@@ -0,0 +1,78 @@
+package software_finder
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+)
+
+// RegistryKey Values mirror the predefined Windows registry key handles
+type RegistryKey uintptr
+
+const (
+	RegistryKeyCurrentUser  RegistryKey = 0x80000001
+	RegistryKeyLocalMachine RegistryKey = 0x80000002
+)
+
+// There is no native registry to query, so registry based configs are looked up in the default Wine prefix instead
+func (f *SoftwareFinder) getInstallDirFromRegistry(config Config) (string, error) {
+	prefix, err := GetDefaultWinePrefix()
+	if err != nil {
+		return "", err
+	}
+
+	config.WinePrefix = prefix
+	return f.getInstallDirFromWinePrefix(config)
+}
+
+// Uninstall entries are also read from the default Wine prefix, mapping any install locations to host paths
+func (f *SoftwareFinder) getUninstallEntries(k RegistryKey, path string) ([]uninstallEntry, error) {
+	prefix, err := GetDefaultWinePrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, content, err := f.readWineRegistry(prefix, k)
+	if err != nil {
+		return nil, err
+	}
+
+	subKeys, err := getWineRegistrySubKeyValues(content, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort sub keys by name to get a stable order (as when enumerating the Windows registry)
+	names := make([]string, 0, len(subKeys))
+	for name := range subKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]uninstallEntry, 0, len(subKeys))
+	for _, name := range names {
+		values := subKeys[name]
+		displayName, ok := values[strings.ToLower(uninstallDisplayNameValueName)]
+		if !ok {
+			continue
+		}
+
+		var installLocation string
+		if location := values[strings.ToLower(uninstallLocationValueName)]; location != "" {
+			// Ignore any install locations we cannot map (uninstall entries are not validated by Windows either)
+			installLocation, _ = toWineHostPath(prefix, location)
+		}
+
+		entries = append(entries, uninstallEntry{
+			DisplayName:     displayName,
+			InstallLocation: installLocation,
+		})
+	}
+
+	return entries, nil
+}
+
+func isRegistryNotExist(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || isWineRegistryNotExist(err)
+}
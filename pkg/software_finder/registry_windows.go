@@ -0,0 +1,54 @@
+package software_finder
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// RegistryKey is an alias (rather than a distinct type), so any repository working with registry.Key satisfies RegistryRepository
+type RegistryKey = registry.Key
+
+const (
+	RegistryKeyCurrentUser  = registry.CURRENT_USER
+	RegistryKeyLocalMachine = registry.LOCAL_MACHINE
+)
+
+func (f *SoftwareFinder) getInstallDirFromRegistry(config Config) (string, error) {
+	return f.registryRepository.GetStringValue(config.RegistryKey, config.RegistryPath, config.RegistryValueName)
+}
+
+func (f *SoftwareFinder) getUninstallEntries(k RegistryKey, path string) ([]uninstallEntry, error) {
+	names, err := f.registryRepository.GetSubKeyNames(k, path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]uninstallEntry, 0, len(names))
+	for _, name := range names {
+		entryPath := path + "\\" + name
+		displayName, err := f.registryRepository.GetStringValue(k, entryPath, uninstallDisplayNameValueName)
+		if err != nil {
+			if isRegistryNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		installLocation, err := f.registryRepository.GetStringValue(k, entryPath, uninstallLocationValueName)
+		if err != nil && !isRegistryNotExist(err) {
+			return nil, err
+		}
+
+		entries = append(entries, uninstallEntry{
+			DisplayName:     displayName,
+			InstallLocation: installLocation,
+		})
+	}
+
+	return entries, nil
+}
+
+func isRegistryNotExist(err error) bool {
+	return errors.Is(err, registry.ErrNotExist)
+}
@@ -1,35 +1,39 @@
 package software_finder
 
 import (
-	"errors"
 	"fmt"
 	"path/filepath"
-
-	"golang.org/x/sys/windows/registry"
 )
 
 type FinderType string
-type RegistryKey registry.Key
 type PathType int
 
 const (
 	RegistryFinder FinderType = "RegistryFinder"
 	PathFinder     FinderType = "PathFinder"
-
-	RegistryKeyCurrentUser  = RegistryKey(registry.CURRENT_USER)
-	RegistryKeyLocalMachine = RegistryKey(registry.LOCAL_MACHINE)
+	// WinePrefixFinder Reads the registry files of a Wine prefix (or Proton compatdata dir) instead of the native registry
+	WinePrefixFinder FinderType = "WinePrefixFinder"
+	// SteamFinder Reads the app manifests of any Steam libraries to find an app's install dir
+	SteamFinder FinderType = "SteamFinder"
+	// GOGFinder Looks up a GOG game in the GOG registry tree or, if an install path is given, in that library path
+	GOGFinder FinderType = "GOGFinder"
+	// UninstallFinder Searches the Windows uninstall entries for one with a matching display name
+	UninstallFinder FinderType = "UninstallFinder"
 
 	PathTypeFile = iota
 	PathTypeDir
 )
 
 type RegistryRepository interface {
-	GetStringValue(k registry.Key, path string, valueName string) (string, error)
+	GetStringValue(k RegistryKey, path string, valueName string) (string, error)
+	GetSubKeyNames(k RegistryKey, path string) ([]string, error)
 }
 
 type FileRepository interface {
 	FileExists(path string) (bool, error)
 	DirExists(path string) (bool, error)
+	ReadFile(path string) ([]byte, error)
+	Glob(pattern string) ([]string, error)
 }
 
 type Config struct {
@@ -39,6 +43,11 @@ type Config struct {
 	RegistryValueName string
 	InstallPath       string
 	PathType          PathType
+	WinePrefix        string
+	SteamAppID        string
+	GOGGameID         string
+	// DisplayNamePattern Regular expression to match against the display name of uninstall entries
+	DisplayNamePattern string
 }
 
 type SoftwareFinder struct {
@@ -73,6 +82,14 @@ func (f *SoftwareFinder) IsInstalled(config Config) (bool, error) {
 	switch config.ForType {
 	case PathFinder:
 		return f.isInstalledAccordingToPath(config)
+	case WinePrefixFinder:
+		return f.isInstalledAccordingToWinePrefix(config)
+	case SteamFinder:
+		return f.isInstalledAccordingToSteam(config)
+	case GOGFinder:
+		return f.isInstalledAccordingToGOG(config)
+	case UninstallFinder:
+		return f.isInstalledAccordingToUninstallRegistry(config)
 	default:
 		return f.isInstalledAccordingToRegistry(config)
 	}
@@ -82,7 +99,7 @@ func (f *SoftwareFinder) isInstalledAccordingToRegistry(config Config) (bool, er
 	_, err := f.getInstallDirFromRegistry(config)
 
 	if err != nil {
-		if errors.Is(err, registry.ErrNotExist) {
+		if isRegistryNotExist(err) {
 			return false, nil
 		}
 		return false, err
@@ -145,6 +162,14 @@ func (f *SoftwareFinder) getInstallDir(config Config) (string, error) {
 	switch config.ForType {
 	case PathFinder:
 		return f.getInstallDirFromPath(config)
+	case WinePrefixFinder:
+		return f.getInstallDirFromWinePrefix(config)
+	case SteamFinder:
+		return f.getInstallDirFromSteam(config)
+	case GOGFinder:
+		return f.getInstallDirFromGOG(config)
+	case UninstallFinder:
+		return f.getInstallDirFromUninstallRegistry(config)
 	default:
 		return f.getInstallDirFromRegistry(config)
 	}
@@ -160,7 +185,3 @@ func (f *SoftwareFinder) getInstallDirFromPath(config Config) (string, error) {
 		return "", fmt.Errorf("unsupported path type: %d", config.PathType)
 	}
 }
-
-func (f *SoftwareFinder) getInstallDirFromRegistry(config Config) (string, error) {
-	return f.registryRepository.GetStringValue(registry.Key(config.RegistryKey), config.RegistryPath, config.RegistryValueName)
-}
@@ -10,7 +10,6 @@ import (
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
-	registry "golang.org/x/sys/windows/registry"
 )
 
 // MockRegistryRepository is a mock of RegistryRepository interface.
@@ -37,7 +36,7 @@ func (m *MockRegistryRepository) EXPECT() *MockRegistryRepositoryMockRecorder {
 }
 
 // GetStringValue mocks base method.
-func (m *MockRegistryRepository) GetStringValue(k registry.Key, path, valueName string) (string, error) {
+func (m *MockRegistryRepository) GetStringValue(k RegistryKey, path, valueName string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStringValue", k, path, valueName)
 	ret0, _ := ret[0].(string)
@@ -51,6 +50,21 @@ func (mr *MockRegistryRepositoryMockRecorder) GetStringValue(k, path, valueName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStringValue", reflect.TypeOf((*MockRegistryRepository)(nil).GetStringValue), k, path, valueName)
 }
 
+// GetSubKeyNames mocks base method.
+func (m *MockRegistryRepository) GetSubKeyNames(k RegistryKey, path string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubKeyNames", k, path)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubKeyNames indicates an expected call of GetSubKeyNames.
+func (mr *MockRegistryRepositoryMockRecorder) GetSubKeyNames(k, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubKeyNames", reflect.TypeOf((*MockRegistryRepository)(nil).GetSubKeyNames), k, path)
+}
+
 // MockFileRepository is a mock of FileRepository interface.
 type MockFileRepository struct {
 	ctrl     *gomock.Controller
@@ -103,3 +117,33 @@ func (mr *MockFileRepositoryMockRecorder) FileExists(path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileExists", reflect.TypeOf((*MockFileRepository)(nil).FileExists), path)
 }
+
+// Glob mocks base method.
+func (m *MockFileRepository) Glob(pattern string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Glob", pattern)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Glob indicates an expected call of Glob.
+func (mr *MockFileRepositoryMockRecorder) Glob(pattern any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Glob", reflect.TypeOf((*MockFileRepository)(nil).Glob), pattern)
+}
+
+// ReadFile mocks base method.
+func (m *MockFileRepository) ReadFile(path string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile.
+func (mr *MockFileRepositoryMockRecorder) ReadFile(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockFileRepository)(nil).ReadFile), path)
+}
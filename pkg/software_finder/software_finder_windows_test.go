@@ -33,7 +33,7 @@ func TestSoftwareFinder_IsInstalledAnywhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 			},
 			wantIsInstalledAnywhere: true,
 		},
@@ -59,8 +59,8 @@ func TestSoftwareFinder_IsInstalledAnywhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyCurrentUser), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			wantIsInstalledAnywhere: true,
@@ -81,7 +81,7 @@ func TestSoftwareFinder_IsInstalledAnywhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(false, nil)
 			},
 			wantIsInstalledAnywhere: false,
@@ -102,7 +102,7 @@ func TestSoftwareFinder_IsInstalledAnywhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", fmt.Errorf("some-error-that-is-not-returned"))
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", fmt.Errorf("some-error-that-is-not-returned"))
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			wantIsInstalledAnywhere: true,
@@ -123,7 +123,7 @@ func TestSoftwareFinder_IsInstalledAnywhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(false, fmt.Errorf("some-error-that-is-returned"))
 			},
 			wantErrContains: "some-error-that-is-returned",
@@ -171,7 +171,7 @@ func TestSoftwareFinder_IsInstalled(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 			},
 			wantIsInstalled: true,
 		},
@@ -184,7 +184,7 @@ func TestSoftwareFinder_IsInstalled(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyCurrentUser), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 			},
 			wantIsInstalled: true,
 		},
@@ -221,7 +221,7 @@ func TestSoftwareFinder_IsInstalled(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 			},
 			wantIsInstalled: false,
 		},
@@ -258,7 +258,7 @@ func TestSoftwareFinder_IsInstalled(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", fmt.Errorf("some-error"))
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", fmt.Errorf("some-error"))
 			},
 			wantErrContains: "some-error",
 		},
@@ -341,7 +341,7 @@ func TestSoftwareFinder_GetInstallDirFromSomewhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			expectedInstallDir: "C:\\Some\\Game",
@@ -368,8 +368,8 @@ func TestSoftwareFinder_GetInstallDirFromSomewhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyCurrentUser), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			expectedInstallDir: "C:\\Some\\Game",
@@ -390,7 +390,7 @@ func TestSoftwareFinder_GetInstallDirFromSomewhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", fmt.Errorf("some-error-that-is-not-returned"))
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", fmt.Errorf("some-error-that-is-not-returned"))
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			expectedInstallDir: "C:\\Some\\Game",
@@ -411,7 +411,7 @@ func TestSoftwareFinder_GetInstallDirFromSomewhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(false, fmt.Errorf("some-error-that-is-returned"))
 			},
 			wantErrContains: "some-error-that-is-returned",
@@ -432,7 +432,7 @@ func TestSoftwareFinder_GetInstallDirFromSomewhere(t *testing.T) {
 				},
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(false, nil)
 				fr.EXPECT().DirExists("C:\\Some").Return(false, nil)
 			},
@@ -481,7 +481,7 @@ func TestSoftwareFinder_GetInstallDir(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			expectedInstallDir: "C:\\Some\\Game",
@@ -495,7 +495,7 @@ func TestSoftwareFinder_GetInstallDir(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyCurrentUser), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
 			expectedInstallDir: "C:\\Some\\Game",
@@ -509,7 +509,7 @@ func TestSoftwareFinder_GetInstallDir(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game\\launch.exe", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game\\launch.exe", nil)
 				fr.EXPECT().DirExists("C:\\Some\\Game\\launch.exe").Return(false, nil)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(true, nil)
 			},
@@ -548,7 +548,7 @@ func TestSoftwareFinder_GetInstallDir(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("", registry.ErrNotExist)
 			},
 			wantErrContains: "The system cannot find the file specified",
 		},
@@ -561,7 +561,7 @@ func TestSoftwareFinder_GetInstallDir(t *testing.T) {
 				RegistryValueName: "InstallDir",
 			},
 			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
-				rr.EXPECT().GetStringValue(registry.Key(RegistryKeyLocalMachine), "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\some\\game", "InstallDir").Return("C:\\Some\\Game", nil)
 				fr.EXPECT().DirExists("C:\\Some\\Game").Return(false, fmt.Errorf("some-error"))
 			},
 			wantErrContains: "some-error",
@@ -618,3 +618,25 @@ func getFinderWithDependencies(t *testing.T) (*SoftwareFinder, *MockRegistryRepo
 	mockFileRepository := NewMockFileRepository(ctrl)
 	return New(mockRegistryRepository, mockFileRepository), mockRegistryRepository, mockFileRepository
 }
+
+func TestSoftwareFinder_GetInstallDir_GOGFinderWithRegistry(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	mockRepository := NewMockRegistryRepository(ctrl)
+	mockFileRepository := NewMockFileRepository(ctrl)
+	finder := New(mockRepository, mockFileRepository)
+
+	// EXPECT
+	mockRepository.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\WOW6432Node\\GOG.com\\Games\\1409964317", "PATH").Return("C:\\GOG Games\\SWAT 4", nil)
+	mockFileRepository.EXPECT().DirExists("C:\\GOG Games\\SWAT 4").Return(true, nil)
+
+	// WHEN
+	installDir, err := finder.GetInstallDir(Config{
+		ForType:   GOGFinder,
+		GOGGameID: "1409964317",
+	})
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, "C:\\GOG Games\\SWAT 4", installDir)
+}
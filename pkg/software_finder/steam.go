@@ -0,0 +1,155 @@
+package software_finder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const (
+	steamAppsDirName             = "steamapps"
+	steamCommonDirName           = "common"
+	steamLibraryFoldersFileName  = "libraryfolders.vdf"
+	steamAppManifestTemplate     = "appmanifest_%s.acf"
+	steamAppStateFlagFullyLoaded = 4
+)
+
+func (f *SoftwareFinder) isInstalledAccordingToSteam(config Config) (bool, error) {
+	_, err := f.getInstallDirFromSteam(config)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (f *SoftwareFinder) getInstallDirFromSteam(config Config) (string, error) {
+	if config.SteamAppID == "" {
+		return "", fmt.Errorf("Steam app id is missing from config")
+	}
+
+	roots, err := f.getSteamRootDirs()
+	if err != nil {
+		return "", err
+	}
+
+	// Errors are only returned if the app could not be found in any of the libraries which could be read
+	var errs []error
+	for _, root := range roots {
+		libraries, err := f.getSteamLibraryDirs(root)
+		if err != nil {
+			// Library folders only add to the Steam install dir, which can still be searched on its own
+			errs = append(errs, err)
+			libraries = []string{root}
+		}
+
+		for _, library := range libraries {
+			installDir, err := f.getSteamAppInstallDir(library, config.SteamAppID)
+			if err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					errs = append(errs, fmt.Errorf("%s: %w", library, err))
+				}
+				continue
+			}
+			return installDir, nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return "", fmt.Errorf("Steam app could not be found in any readable library: %s: %w", config.SteamAppID, errors.Join(errs...))
+	}
+
+	return "", fmt.Errorf("Steam app is not installed in any library: %s: %w", config.SteamAppID, os.ErrNotExist)
+}
+
+// getSteamLibraryDirs Returns the Steam install dir itself plus any library folders listed in its libraryfolders.vdf
+// (which may not exist, e.g. if no additional library folders were ever added)
+func (f *SoftwareFinder) getSteamLibraryDirs(root string) ([]string, error) {
+	content, err := f.fileRepository.ReadFile(filepath.Join(root, steamAppsDirName, steamLibraryFoldersFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{root}, nil
+		}
+		return nil, fmt.Errorf("failed to read Steam library folders: %s", err)
+	}
+
+	vdf, err := parseVDF(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Steam library folders: %s", err)
+	}
+
+	folders, ok := vdf.getObject("libraryfolders")
+	if !ok {
+		return nil, fmt.Errorf("failed to parse Steam library folders: libraryfolders key is missing")
+	}
+
+	// Folders are keyed by index (starting at 1 in the old format, which only lists additional folders)
+	indices := make([]int, 0, len(folders))
+	for key := range folders {
+		if index, err := strconv.Atoi(key); err == nil {
+			indices = append(indices, index)
+		}
+	}
+	sort.Ints(indices)
+
+	libraries := []string{root}
+	for _, index := range indices {
+		// Value is either just the path (old format) or an object containing it
+		key := strconv.Itoa(index)
+		path, ok := folders.getString(key)
+		if !ok {
+			folder, _ := folders.getObject(key)
+			if path, ok = folder.getString("path"); !ok {
+				continue
+			}
+		}
+		if filepath.Clean(path) != filepath.Clean(root) {
+			libraries = append(libraries, path)
+		}
+	}
+
+	return libraries, nil
+}
+
+func (f *SoftwareFinder) getSteamAppInstallDir(library string, appID string) (string, error) {
+	steamApps := filepath.Join(library, steamAppsDirName)
+	content, err := f.fileRepository.ReadFile(filepath.Join(steamApps, fmt.Sprintf(steamAppManifestTemplate, appID)))
+	if err != nil {
+		return "", err
+	}
+
+	vdf, err := parseVDF(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Steam app manifest: %s", err)
+	}
+
+	state, ok := vdf.getObject("AppState")
+	if !ok {
+		return "", fmt.Errorf("failed to parse Steam app manifest: AppState key is missing")
+	}
+
+	// Apps which are still being downloaded (or were partially uninstalled) also have a manifest, but are not usable
+	if value, ok := state.getString("StateFlags"); ok {
+		flags, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse Steam app state flags: %s", err)
+		}
+		if flags&steamAppStateFlagFullyLoaded == 0 {
+			return "", fmt.Errorf("Steam app is not fully installed: %s: %w", appID, os.ErrNotExist)
+		}
+	}
+
+	installDir, ok := state.getString("installdir")
+	if !ok || installDir == "" {
+		return "", fmt.Errorf("failed to parse Steam app manifest: installdir key is missing")
+	}
+
+	return filepath.Join(steamApps, steamCommonDirName, installDir), nil
+}
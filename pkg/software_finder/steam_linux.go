@@ -0,0 +1,22 @@
+package software_finder
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// getSteamRootDirs Returns the default Steam install dirs, covering native, Flatpak and Snap installs
+func (f *SoftwareFinder) getSteamRootDirs() ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		filepath.Join(home, ".local", "share", "Steam"),
+		// Usually a symlink to the above, but may point elsewhere for (older) custom installs
+		filepath.Join(home, ".steam", "steam"),
+		filepath.Join(home, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam"),
+		filepath.Join(home, "snap", "steam", "common", ".local", "share", "Steam"),
+	}, nil
+}
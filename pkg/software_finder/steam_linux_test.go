@@ -0,0 +1,158 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSoftwareFinder_GetInstallDir_SteamFinder(t *testing.T) {
+	type test struct {
+		name               string
+		givenConfig        Config
+		expect             func(fr *MockFileRepository)
+		expectedInstallDir string
+		wantErrContains    string
+	}
+
+	t.Setenv("HOME", "/home/user")
+	steamRoot := "/home/user/.local/share/Steam"
+	tests := []test{
+		{
+			name: "successfully determines install dir via Steam library folder",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf")).Return([]byte(testSteamLibraryFolders), nil)
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "appmanifest_13240.acf")).Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile("/steam/steamapps/appmanifest_13240.acf").Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile("/library/steamapps/appmanifest_13240.acf").Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists("/library/steamapps/common/Unreal Tournament").Return(true, nil)
+			},
+			expectedInstallDir: "/library/steamapps/common/Unreal Tournament",
+		},
+		{
+			name: "successfully determines install dir via Steam root without library folders file",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf")).Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "appmanifest_13240.acf")).Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists(filepath.Join(steamRoot, "steamapps", "common", "Unreal Tournament")).Return(true, nil)
+			},
+			expectedInstallDir: "/home/user/.local/share/Steam/steamapps/common/Unreal Tournament",
+		},
+		{
+			name: "successfully determines install dir via Steam root with invalid library folders file",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf")).Return([]byte(`"libraryfolders" {`), nil)
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "appmanifest_13240.acf")).Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists(filepath.Join(steamRoot, "steamapps", "common", "Unreal Tournament")).Return(true, nil)
+			},
+			expectedInstallDir: "/home/user/.local/share/Steam/steamapps/common/Unreal Tournament",
+		},
+		{
+			name: "successfully determines install dir via Steam library folder after unreadable app manifest",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf")).Return([]byte(testSteamLibraryFolders), nil)
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "appmanifest_13240.acf")).Return(nil, os.ErrPermission)
+				fr.EXPECT().ReadFile("/steam/steamapps/appmanifest_13240.acf").Return([]byte(`"AppState" {`), nil)
+				fr.EXPECT().ReadFile("/library/steamapps/appmanifest_13240.acf").Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists("/library/steamapps/common/Unreal Tournament").Return(true, nil)
+			},
+			expectedInstallDir: "/library/steamapps/common/Unreal Tournament",
+		},
+		{
+			name: "successfully determines install dir via Flatpak Steam install",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				flatpakRoot := "/home/user/.var/app/com.valvesoftware.Steam/.local/share/Steam"
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf")).Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "appmanifest_13240.acf")).Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile("/home/user/.steam/steam/steamapps/libraryfolders.vdf").Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile("/home/user/.steam/steam/steamapps/appmanifest_13240.acf").Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile(filepath.Join(flatpakRoot, "steamapps", "libraryfolders.vdf")).Return([]byte(`"libraryfolders" {}`), nil)
+				fr.EXPECT().ReadFile(filepath.Join(flatpakRoot, "steamapps", "appmanifest_13240.acf")).Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists(filepath.Join(flatpakRoot, "steamapps", "common", "Unreal Tournament")).Return(true, nil)
+			},
+			expectedInstallDir: "/home/user/.var/app/com.valvesoftware.Steam/.local/share/Steam/steamapps/common/Unreal Tournament",
+		},
+		{
+			name: "errors if app is not installed in any library",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				// Library folders file and app manifest for each Steam root
+				fr.EXPECT().ReadFile(gomock.Any()).Return(nil, os.ErrNotExist).Times(8)
+			},
+			wantErrContains: "Steam app is not installed in any library: 13240",
+		},
+		{
+			name: "errors if app is not installed in any readable library",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf")).Return([]byte(`"libraryfolders" {`), nil)
+				fr.EXPECT().ReadFile(filepath.Join(steamRoot, "steamapps", "appmanifest_13240.acf")).Return(nil, os.ErrPermission)
+				fr.EXPECT().ReadFile(gomock.Any()).Return(nil, os.ErrNotExist).Times(6)
+			},
+			wantErrContains: "Steam app could not be found in any readable library: 13240: failed to parse Steam library folders",
+		},
+		{
+			name: "errors for missing app id",
+			givenConfig: Config{
+				ForType: SteamFinder,
+			},
+			expect:          func(fr *MockFileRepository) {},
+			wantErrContains: "Steam app id is missing from config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(NewMockRegistryRepository(ctrl), mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.GetInstallDir(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInstallDir, installDir)
+			}
+		})
+	}
+}
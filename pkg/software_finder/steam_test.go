@@ -0,0 +1,200 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testSteamLibraryFolders = `"libraryfolders"
+{
+	"0"
+	{
+		"path"		"/steam"
+		"label"		""
+		"apps"
+		{
+			"13240"		"25000000"
+		}
+	}
+	"1"
+	{
+		"path"		"/library"
+		"label"		""
+	}
+}`
+	testSteamLibraryFoldersLegacy = `"LibraryFolders"
+{
+	"TimeNextStatsReport"		"1600000000"
+	"ContentStatsID"		"-1234"
+	"1"		"/library"
+}`
+	testSteamAppManifest = `"AppState"
+{
+	"appid"		"13240"
+	"name"		"Unreal Tournament: Game of the Year Edition"
+	"StateFlags"		"4"
+	"installdir"		"Unreal Tournament"
+}`
+	testSteamAppManifestUpdating = `"AppState"
+{
+	"appid"		"13240"
+	"StateFlags"		"1026"
+	"installdir"		"Unreal Tournament"
+}`
+)
+
+func TestSoftwareFinder_getSteamLibraryDirs(t *testing.T) {
+	type test struct {
+		name            string
+		expect          func(fr *MockFileRepository)
+		wantLibraries   []string
+		wantErrContains string
+	}
+
+	root := filepath.FromSlash("/steam")
+	tests := []test{
+		{
+			name: "returns root and library folders",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")).Return([]byte(testSteamLibraryFolders), nil)
+			},
+			wantLibraries: []string{root, "/library"},
+		},
+		{
+			name: "returns root and library folders from legacy format",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")).Return([]byte(testSteamLibraryFoldersLegacy), nil)
+			},
+			wantLibraries: []string{root, "/library"},
+		},
+		{
+			name: "returns root only if library folders file does not exist",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")).Return(nil, os.ErrNotExist)
+			},
+			wantLibraries: []string{root},
+		},
+		{
+			name: "errors if library folders file cannot be read",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")).Return(nil, os.ErrPermission)
+			},
+			wantErrContains: "failed to read Steam library folders: permission denied",
+		},
+		{
+			name: "errors for unparsable library folders",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")).Return([]byte(`"libraryfolders" {`), nil)
+			},
+			wantErrContains: "failed to parse Steam library folders",
+		},
+		{
+			name: "errors for invalid library folders",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")).Return([]byte(`"something" "else"`), nil)
+			},
+			wantErrContains: "libraryfolders key is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(NewMockRegistryRepository(ctrl), mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			libraries, err := finder.getSteamLibraryDirs(root)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantLibraries, libraries)
+			}
+		})
+	}
+}
+
+func TestSoftwareFinder_getSteamAppInstallDir(t *testing.T) {
+	type test struct {
+		name            string
+		expect          func(fr *MockFileRepository)
+		wantInstallDir  string
+		wantNotExist    bool
+		wantErrContains string
+	}
+
+	library := filepath.FromSlash("/library")
+	manifestPath := filepath.Join(library, "steamapps", "appmanifest_13240.acf")
+	tests := []test{
+		{
+			name: "returns install dir of fully installed app",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(manifestPath).Return([]byte(testSteamAppManifest), nil)
+			},
+			wantInstallDir: filepath.Join(library, "steamapps", "common", "Unreal Tournament"),
+		},
+		{
+			name: "errors with not exist for app which is not fully installed",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(manifestPath).Return([]byte(testSteamAppManifestUpdating), nil)
+			},
+			wantNotExist:    true,
+			wantErrContains: "Steam app is not fully installed: 13240",
+		},
+		{
+			name: "errors with not exist for app not in library",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(manifestPath).Return(nil, os.ErrNotExist)
+			},
+			wantNotExist:    true,
+			wantErrContains: os.ErrNotExist.Error(),
+		},
+		{
+			name: "errors for manifest without install dir",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(manifestPath).Return([]byte(`"AppState" { "appid" "13240" }`), nil)
+			},
+			wantErrContains: "installdir key is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(NewMockRegistryRepository(ctrl), mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.getSteamAppInstallDir(library, "13240")
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+				assert.Equal(t, tt.wantNotExist, errors.Is(err, os.ErrNotExist))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantInstallDir, installDir)
+			}
+		})
+	}
+}
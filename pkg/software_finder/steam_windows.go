@@ -0,0 +1,36 @@
+package software_finder
+
+import (
+	"path/filepath"
+)
+
+// getSteamRootDirs Returns the Steam install dir as stored in the registry by the Steam client (user and machine wide)
+func (f *SoftwareFinder) getSteamRootDirs() ([]string, error) {
+	candidates := []Config{
+		{
+			RegistryKey:       RegistryKeyCurrentUser,
+			RegistryPath:      "SOFTWARE\\Valve\\Steam",
+			RegistryValueName: "SteamPath",
+		},
+		{
+			RegistryKey:       RegistryKeyLocalMachine,
+			RegistryPath:      "SOFTWARE\\WOW6432Node\\Valve\\Steam",
+			RegistryValueName: "InstallPath",
+		},
+	}
+
+	roots := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		root, err := f.getInstallDirFromRegistry(candidate)
+		if err != nil {
+			if isRegistryNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		// SteamPath uses forward slashes (e.g. c:/program files (x86)/steam)
+		roots = append(roots, filepath.Clean(filepath.FromSlash(root)))
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,93 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/sys/windows/registry"
+)
+
+func TestSoftwareFinder_GetInstallDir_SteamFinder(t *testing.T) {
+	type test struct {
+		name               string
+		givenConfig        Config
+		expect             func(rr *MockRegistryRepository, fr *MockFileRepository)
+		expectedInstallDir string
+		wantErrContains    string
+	}
+
+	tests := []test{
+		{
+			name: "successfully determines install dir via Steam path from registry",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\Valve\\Steam", "SteamPath").Return("c:/program files (x86)/steam", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\WOW6432Node\\Valve\\Steam", "InstallPath").Return("", registry.ErrNotExist)
+				fr.EXPECT().ReadFile("c:\\program files (x86)\\steam\\steamapps\\libraryfolders.vdf").Return([]byte(`"libraryfolders" { "1" { "path" "D:\\SteamLibrary" } }`), nil)
+				fr.EXPECT().ReadFile("c:\\program files (x86)\\steam\\steamapps\\appmanifest_13240.acf").Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile("D:\\SteamLibrary\\steamapps\\appmanifest_13240.acf").Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists("D:\\SteamLibrary\\steamapps\\common\\Unreal Tournament").Return(true, nil)
+			},
+			expectedInstallDir: "D:\\SteamLibrary\\steamapps\\common\\Unreal Tournament",
+		},
+		{
+			name: "successfully determines install dir via Steam path without library folders file",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\Valve\\Steam", "SteamPath").Return("c:/program files (x86)/steam", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\WOW6432Node\\Valve\\Steam", "InstallPath").Return("", registry.ErrNotExist)
+				fr.EXPECT().ReadFile("c:\\program files (x86)\\steam\\steamapps\\libraryfolders.vdf").Return(nil, os.ErrNotExist)
+				fr.EXPECT().ReadFile("c:\\program files (x86)\\steam\\steamapps\\appmanifest_13240.acf").Return([]byte(testSteamAppManifest), nil)
+				fr.EXPECT().DirExists("c:\\program files (x86)\\steam\\steamapps\\common\\Unreal Tournament").Return(true, nil)
+			},
+			expectedInstallDir: "c:\\program files (x86)\\steam\\steamapps\\common\\Unreal Tournament",
+		},
+		{
+			name: "errors if Steam is not installed",
+			givenConfig: Config{
+				ForType:    SteamFinder,
+				SteamAppID: "13240",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, "SOFTWARE\\Valve\\Steam", "SteamPath").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, "SOFTWARE\\WOW6432Node\\Valve\\Steam", "InstallPath").Return("", registry.ErrNotExist)
+			},
+			wantErrContains: "Steam app is not installed in any library: 13240",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockRegistryRepository(ctrl)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(mockRepository, mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockRepository, mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.GetInstallDir(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInstallDir, installDir)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package software_finder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+const (
+	uninstallRegistryPath            = "SOFTWARE\\Microsoft\\Windows\\CurrentVersion\\Uninstall"
+	uninstallRegistryPathWOW6432Node = "SOFTWARE\\WOW6432Node\\Microsoft\\Windows\\CurrentVersion\\Uninstall"
+	uninstallDisplayNameValueName    = "DisplayName"
+	uninstallLocationValueName       = "InstallLocation"
+)
+
+type uninstallEntry struct {
+	DisplayName     string
+	InstallLocation string
+}
+
+func (f *SoftwareFinder) isInstalledAccordingToUninstallRegistry(config Config) (bool, error) {
+	_, err := f.getInstallDirFromUninstallRegistry(config)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// getInstallDirFromUninstallRegistry Searches the (machine and user wide) uninstall entries for one with a matching
+// display name, returning its install location
+func (f *SoftwareFinder) getInstallDirFromUninstallRegistry(config Config) (string, error) {
+	if config.DisplayNamePattern == "" {
+		return "", fmt.Errorf("display name pattern is missing from config")
+	}
+
+	pattern, err := regexp.Compile(config.DisplayNamePattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid display name pattern: %s", err)
+	}
+
+	roots := []struct {
+		key  RegistryKey
+		path string
+	}{
+		{key: RegistryKeyLocalMachine, path: uninstallRegistryPathWOW6432Node},
+		{key: RegistryKeyLocalMachine, path: uninstallRegistryPath},
+		{key: RegistryKeyCurrentUser, path: uninstallRegistryPath},
+	}
+
+	for _, root := range roots {
+		entries, err := f.getUninstallEntries(root.key, root.path)
+		if err != nil {
+			if isRegistryNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+
+		for _, entry := range entries {
+			// Many installers do not set an install location, so we can only use entries which do
+			if entry.InstallLocation != "" && pattern.MatchString(entry.DisplayName) {
+				return entry.InstallLocation, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no uninstall entry matches display name pattern %s: %w", config.DisplayNamePattern, os.ErrNotExist)
+}
@@ -0,0 +1,96 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testWineUninstallRegistry = `WINE REGISTRY Version 2
+;; All keys relative to \\Machine
+
+[Software\\Microsoft\\Windows\\CurrentVersion\\Uninstall\\Wine Gecko] 1700000000
+"DisplayName"="Wine Gecko"
+"InstallLocation"="C:\\windows\\system32\\gecko"
+
+[Software\\Wow6432Node\\Microsoft\\Windows\\CurrentVersion\\Uninstall\\{some-guid}] 1700000000
+"DisplayName"="Battlefield 2"
+
+[Software\\Wow6432Node\\Microsoft\\Windows\\CurrentVersion\\Uninstall\\{some-guid}\\Nested] 1700000000
+"DisplayName"="Battlefield 2"
+"InstallLocation"="C:\\Nested"
+
+[Software\\Wow6432Node\\Microsoft\\Windows\\CurrentVersion\\Uninstall\\Battlefield 2 Repack] 1700000000
+"DisplayName"="Battlefield 2"
+"InstallLocation"="C:\\Games\\Battlefield 2"
+`
+)
+
+func TestSoftwareFinder_GetInstallDir_UninstallFinder(t *testing.T) {
+	type test struct {
+		name               string
+		givenConfig        Config
+		expect             func(fr *MockFileRepository)
+		expectedInstallDir string
+		wantErrContains    string
+	}
+
+	t.Setenv("WINEPREFIX", "/prefix")
+	tests := []test{
+		{
+			name: "successfully determines install dir via matching uninstall entry in default wine prefix",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "^Battlefield 2$",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists("/prefix/pfx").Return(false, nil)
+				fr.EXPECT().ReadFile("/prefix/system.reg").Return([]byte(testWineUninstallRegistry), nil)
+				fr.EXPECT().DirExists("/prefix/drive_c/Games/Battlefield 2").Return(true, nil)
+			},
+			expectedInstallDir: "/prefix/drive_c/Games/Battlefield 2",
+		},
+		{
+			name: "errors if no uninstall entry matches",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "^Battlefield 1942$",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists("/prefix/pfx").Return(false, nil).Times(3)
+				fr.EXPECT().ReadFile("/prefix/system.reg").Return([]byte(testWineUninstallRegistry), nil).Times(2)
+				fr.EXPECT().ReadFile("/prefix/user.reg").Return(nil, os.ErrNotExist)
+			},
+			wantErrContains: "no uninstall entry matches display name pattern ^Battlefield 1942$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(NewMockRegistryRepository(ctrl), mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.GetInstallDir(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInstallDir, installDir)
+			}
+		})
+	}
+}
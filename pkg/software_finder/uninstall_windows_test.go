@@ -0,0 +1,118 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/sys/windows/registry"
+)
+
+func TestSoftwareFinder_GetInstallDir_UninstallFinder(t *testing.T) {
+	type test struct {
+		name               string
+		givenConfig        Config
+		expect             func(rr *MockRegistryRepository, fr *MockFileRepository)
+		expectedInstallDir string
+		wantErrContains    string
+	}
+
+	uninstallPath := "SOFTWARE\\Microsoft\\Windows\\CurrentVersion\\Uninstall"
+	uninstallPathWOW6432Node := "SOFTWARE\\WOW6432Node\\Microsoft\\Windows\\CurrentVersion\\Uninstall"
+	tests := []test{
+		{
+			name: "successfully determines install dir via matching uninstall entry",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "(?i)^battlefield 2$",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetSubKeyNames(RegistryKeyLocalMachine, uninstallPathWOW6432Node).Return([]string{"{some-guid}", "Battlefield 2 Repack", "{other-guid}"}, nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\{some-guid}", "DisplayName").Return("Battlefield 2142", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\{some-guid}", "InstallLocation").Return("C:\\Games\\Battlefield 2142", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\Battlefield 2 Repack", "DisplayName").Return("Battlefield 2", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\Battlefield 2 Repack", "InstallLocation").Return("C:\\Games\\Battlefield 2", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\{other-guid}", "DisplayName").Return("", registry.ErrNotExist)
+				fr.EXPECT().DirExists("C:\\Games\\Battlefield 2").Return(true, nil)
+			},
+			expectedInstallDir: "C:\\Games\\Battlefield 2",
+		},
+		{
+			name: "skips matching uninstall entries without install location",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "^Battlefield 2$",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetSubKeyNames(RegistryKeyLocalMachine, uninstallPathWOW6432Node).Return([]string{"{some-guid}"}, nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\{some-guid}", "DisplayName").Return("Battlefield 2", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyLocalMachine, uninstallPathWOW6432Node+"\\{some-guid}", "InstallLocation").Return("", registry.ErrNotExist)
+				rr.EXPECT().GetSubKeyNames(RegistryKeyLocalMachine, uninstallPath).Return(nil, registry.ErrNotExist)
+				rr.EXPECT().GetSubKeyNames(RegistryKeyCurrentUser, uninstallPath).Return([]string{"Battlefield 2"}, nil)
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, uninstallPath+"\\Battlefield 2", "DisplayName").Return("Battlefield 2", nil)
+				rr.EXPECT().GetStringValue(RegistryKeyCurrentUser, uninstallPath+"\\Battlefield 2", "InstallLocation").Return("D:\\Battlefield 2", nil)
+				fr.EXPECT().DirExists("D:\\Battlefield 2").Return(true, nil)
+			},
+			expectedInstallDir: "D:\\Battlefield 2",
+		},
+		{
+			name: "errors if no uninstall entry matches",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "^Battlefield 2$",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetSubKeyNames(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(3)
+			},
+			wantErrContains: "no uninstall entry matches display name pattern ^Battlefield 2$",
+		},
+		{
+			name: "errors for registry error",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "^Battlefield 2$",
+			},
+			expect: func(rr *MockRegistryRepository, fr *MockFileRepository) {
+				rr.EXPECT().GetSubKeyNames(RegistryKeyLocalMachine, uninstallPathWOW6432Node).Return(nil, fmt.Errorf("some-error"))
+			},
+			wantErrContains: "some-error",
+		},
+		{
+			name: "errors for invalid pattern",
+			givenConfig: Config{
+				ForType:            UninstallFinder,
+				DisplayNamePattern: "^Battlefield (2$",
+			},
+			expect:          func(rr *MockRegistryRepository, fr *MockFileRepository) {},
+			wantErrContains: "invalid display name pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockRegistryRepository(ctrl)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(mockRepository, mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockRepository, mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.GetInstallDir(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInstallDir, installDir)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package software_finder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// vdfObject Valve's KeyValues (VDF) text format, as used by Steam for libraryfolders.vdf and appmanifest_*.acf files.
+// Values are either strings or nested objects. Keys are case-insensitive, so they are stored in lower case.
+type vdfObject map[string]interface{}
+
+func (o vdfObject) getString(key string) (string, bool) {
+	value, ok := o[strings.ToLower(key)].(string)
+	return value, ok
+}
+
+func (o vdfObject) getObject(key string) (vdfObject, bool) {
+	value, ok := o[strings.ToLower(key)].(vdfObject)
+	return value, ok
+}
+
+func parseVDF(content []byte) (vdfObject, error) {
+	tokens, err := tokenizeVDF(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	root, rest, err := parseVDFObject(tokens, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected VDF token: %s", rest[0].value)
+	}
+
+	return root, nil
+}
+
+type vdfToken struct {
+	value string
+	// Whether the token is a (quoted or unquoted) string, rather than a brace
+	isString bool
+}
+
+func parseVDFObject(tokens []vdfToken, nested bool) (vdfObject, []vdfToken, error) {
+	object := vdfObject{}
+	for len(tokens) > 0 {
+		key := tokens[0]
+		if !key.isString {
+			if key.value == "}" && nested {
+				return object, tokens[1:], nil
+			}
+			return nil, nil, fmt.Errorf("unexpected VDF token: %s", key.value)
+		}
+
+		if len(tokens) < 2 {
+			return nil, nil, fmt.Errorf("missing VDF value for key: %s", key.value)
+		}
+
+		value := tokens[1]
+		switch {
+		case value.isString:
+			object[strings.ToLower(key.value)] = value.value
+			tokens = tokens[2:]
+		case value.value == "{":
+			child, rest, err := parseVDFObject(tokens[2:], true)
+			if err != nil {
+				return nil, nil, err
+			}
+			object[strings.ToLower(key.value)] = child
+			tokens = rest
+		default:
+			return nil, nil, fmt.Errorf("unexpected VDF token: %s", value.value)
+		}
+	}
+
+	if nested {
+		return nil, nil, fmt.Errorf("unexpected end of VDF content")
+	}
+
+	return object, nil, nil
+}
+
+func tokenizeVDF(content string) ([]vdfToken, error) {
+	var tokens []vdfToken
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			continue
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			// Skip comment until end of line
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case c == '{' || c == '}':
+			tokens = append(tokens, vdfToken{value: string(c)})
+		case c == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(content) && content[i] != '"'; i++ {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+					switch content[i] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(content[i])
+					}
+					continue
+				}
+				b.WriteByte(content[i])
+			}
+			if i == len(content) {
+				return nil, fmt.Errorf("unterminated VDF string")
+			}
+			tokens = append(tokens, vdfToken{value: b.String(), isString: true})
+		default:
+			// Unquoted strings end at the next whitespace or brace
+			start := i
+			for i < len(content) && !strings.ContainsRune(" \t\r\n{}\"", rune(content[i])) {
+				i++
+			}
+			tokens = append(tokens, vdfToken{value: content[start:i], isString: true})
+			i--
+		}
+	}
+	return tokens, nil
+}
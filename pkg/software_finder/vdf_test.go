@@ -0,0 +1,95 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVDF(t *testing.T) {
+	type test struct {
+		name            string
+		givenContent    string
+		wantObject      vdfObject
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name: "parses nested objects",
+			givenContent: `"AppState"
+{
+	"appid"		"13240"
+	"InstallDir"		"Unreal Tournament"
+	"UserConfig"
+	{
+		"language"		"english"
+	}
+}`,
+			wantObject: vdfObject{
+				"appstate": vdfObject{
+					"appid":      "13240",
+					"installdir": "Unreal Tournament",
+					"userconfig": vdfObject{
+						"language": "english",
+					},
+				},
+			},
+		},
+		{
+			name: "parses escaped strings, unquoted strings and comments",
+			givenContent: `// Some comment
+"libraryfolders"
+{
+	"0"	"C:\\Program Files (x86)\\Steam"
+	"1"	"D:\\Some \"Library\""
+	unquoted value
+}`,
+			wantObject: vdfObject{
+				"libraryfolders": vdfObject{
+					"0":        "C:\\Program Files (x86)\\Steam",
+					"1":        "D:\\Some \"Library\"",
+					"unquoted": "value",
+				},
+			},
+		},
+		{
+			name:            "errors for unclosed object",
+			givenContent:    `"AppState" { "appid" "13240"`,
+			wantErrContains: "unexpected end of VDF content",
+		},
+		{
+			name:            "errors for unexpected closing brace",
+			givenContent:    `"appid" "13240" }`,
+			wantErrContains: "unexpected VDF token: }",
+		},
+		{
+			name:            "errors for missing value",
+			givenContent:    `"appid"`,
+			wantErrContains: "missing VDF value for key: appid",
+		},
+		{
+			name:            "errors for unterminated string",
+			givenContent:    `"appid" "13240`,
+			wantErrContains: "unterminated VDF string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			object, err := parseVDF([]byte(tt.givenContent))
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantObject, object)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package software_finder
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	wineSystemRegistryFileName = "system.reg"
+	wineUserRegistryFileName   = "user.reg"
+	wineDriveCDirName          = "drive_c"
+	wineDosDevicesDirName      = "dosdevices"
+	wineDefaultPrefixDirName   = ".wine"
+	wineRegistryWOW6432Node    = "WOW6432Node\\"
+	wineRegistryExpandSZPrefix = "str(2):"
+	protonPrefixDirName        = "pfx"
+)
+
+// GetDefaultWinePrefix Returns the prefix Wine itself would use by default ($WINEPREFIX, falling back to ~/.wine)
+func GetDefaultWinePrefix() (string, error) {
+	if prefix := os.Getenv("WINEPREFIX"); prefix != "" {
+		return prefix, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, wineDefaultPrefixDirName), nil
+}
+
+// MakeWinePrefixConfigs Derives a WinePrefixFinder config for every RegistryFinder config and prefix,
+// so software can be found in Wine prefixes using the same registry details as on Windows
+func MakeWinePrefixConfigs(configs []Config, prefixes ...string) []Config {
+	wineConfigs := make([]Config, 0, len(configs)*len(prefixes))
+	for _, prefix := range prefixes {
+		for _, config := range configs {
+			if config.ForType != RegistryFinder {
+				continue
+			}
+			// Config is not a pointer, so we can change "it" without affecting the original
+			config.ForType = WinePrefixFinder
+			config.WinePrefix = prefix
+			wineConfigs = append(wineConfigs, config)
+		}
+	}
+	return wineConfigs
+}
+
+func (f *SoftwareFinder) isInstalledAccordingToWinePrefix(config Config) (bool, error) {
+	_, err := f.getInstallDirFromWinePrefix(config)
+
+	if err != nil {
+		if os.IsNotExist(err) || isWineRegistryNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (f *SoftwareFinder) getInstallDirFromWinePrefix(config Config) (string, error) {
+	prefix, content, err := f.readWineRegistry(config.WinePrefix, config.RegistryKey)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := getWineRegistryStringValue(content, config.RegistryPath, config.RegistryValueName)
+	if err != nil {
+		return "", err
+	}
+
+	return toWineHostPath(prefix, value)
+}
+
+// readWineRegistry Reads the registry file matching the given key, returning the resolved prefix along with the content
+func (f *SoftwareFinder) readWineRegistry(prefix string, k RegistryKey) (string, []byte, error) {
+	prefix, err := f.resolveWinePrefix(prefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var registryFileName string
+	switch k {
+	case RegistryKeyLocalMachine:
+		registryFileName = wineSystemRegistryFileName
+	case RegistryKeyCurrentUser:
+		registryFileName = wineUserRegistryFileName
+	default:
+		return "", nil, fmt.Errorf("unsupported registry key for Wine prefix: %d", k)
+	}
+
+	content, err := f.fileRepository.ReadFile(filepath.Join(prefix, registryFileName))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return prefix, content, nil
+}
+
+// resolveWinePrefix Expands the user's home dir and accepts Proton's compatdata/<appid> dir in place of the actual prefix
+func (f *SoftwareFinder) resolveWinePrefix(prefix string) (string, error) {
+	if prefix == "" {
+		return "", fmt.Errorf("Wine prefix is missing from config")
+	}
+
+	if strings.HasPrefix(prefix, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		prefix = filepath.Join(home, prefix[2:])
+	}
+
+	protonPrefix := filepath.Join(prefix, protonPrefixDirName)
+	isProton, err := f.fileRepository.DirExists(protonPrefix)
+	if err != nil {
+		return "", err
+	}
+	if isProton {
+		return protonPrefix, nil
+	}
+
+	return prefix, nil
+}
+
+type errWineRegistryNotExist struct {
+	path      string
+	valueName string
+	// Whether the error refers to (sub) keys rather than a value
+	isKey bool
+}
+
+func (e *errWineRegistryNotExist) Error() string {
+	if e.isKey {
+		return fmt.Sprintf("registry key does not exist in Wine prefix: %s", e.path)
+	}
+	return fmt.Sprintf("registry value does not exist in Wine prefix: %s\\%s", e.path, e.valueName)
+}
+
+func isWineRegistryNotExist(err error) bool {
+	_, ok := err.(*errWineRegistryNotExist)
+	return ok
+}
+
+// getWineRegistryStringValue Reads a string value from a Wine registry file (system.reg/user.reg). 32-bit software
+// is registered below WOW6432Node in 64-bit prefixes only, so any path containing it is also looked up without it.
+func getWineRegistryStringValue(content []byte, path string, valueName string) (string, error) {
+	candidates := []string{path}
+	if i := strings.Index(strings.ToUpper(path), strings.ToUpper(wineRegistryWOW6432Node)); i != -1 {
+		candidates = append(candidates, path[:i]+path[i+len(wineRegistryWOW6432Node):])
+	}
+
+	for _, candidate := range candidates {
+		value, found, err := findWineRegistryStringValue(content, candidate, valueName)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return value, nil
+		}
+	}
+
+	return "", &errWineRegistryNotExist{path: path, valueName: valueName}
+}
+
+func findWineRegistryStringValue(content []byte, path string, valueName string) (string, bool, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	// Lines can be very long (e.g. binary values), so allow up to 1MB per line
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	inKey := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			// Key lines look like: [Software\\Wow6432Node\\EA Games\\Battlefield 2] 1660000000
+			end := strings.LastIndex(line, "]")
+			if end == -1 {
+				inKey = false
+				continue
+			}
+			key, err := unescapeWineRegistryString(line[1:end])
+			if err != nil {
+				return "", false, err
+			}
+			inKey = strings.EqualFold(key, path)
+			continue
+		}
+
+		if !inKey {
+			continue
+		}
+
+		name, value, ok, err := parseWineRegistryStringValueLine(line)
+		if err != nil {
+			return "", false, err
+		}
+		if ok && strings.EqualFold(name, valueName) {
+			return value, true, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", false, err
+	}
+
+	return "", false, nil
+}
+
+// getWineRegistrySubKeyValues Returns the string values of all direct sub keys of the given path (keyed by the
+// lower case sub key name and value name), erroring with errWineRegistryNotExist if there are none
+func getWineRegistrySubKeyValues(content []byte, path string) (map[string]map[string]string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	// Lines can be very long (e.g. binary values), so allow up to 1MB per line
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	prefix := strings.ToLower(path) + "\\"
+	subKeys := map[string]map[string]string{}
+	var current map[string]string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			end := strings.LastIndex(line, "]")
+			if end == -1 {
+				continue
+			}
+			key, err := unescapeWineRegistryString(line[1:end])
+			if err != nil {
+				return nil, err
+			}
+			key = strings.ToLower(key)
+			// Only consider direct sub keys
+			if !strings.HasPrefix(key, prefix) || strings.Contains(key[len(prefix):], "\\") {
+				continue
+			}
+			current = map[string]string{}
+			subKeys[key[len(prefix):]] = current
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		name, value, ok, err := parseWineRegistryStringValueLine(line)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			current[strings.ToLower(name)] = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(subKeys) == 0 {
+		return nil, &errWineRegistryNotExist{path: path, isKey: true}
+	}
+
+	return subKeys, nil
+}
+
+// parseWineRegistryStringValueLine Parses lines like "InstallDir"="C:\\Games" (or @="..." for the default value),
+// ignoring any non-string values
+func parseWineRegistryStringValueLine(line string) (string, string, bool, error) {
+	var name, rest string
+	switch {
+	case strings.HasPrefix(line, "@="):
+		rest = line[2:]
+	case strings.HasPrefix(line, "\""):
+		end := findWineRegistryStringEnd(line)
+		if end == -1 || !strings.HasPrefix(line[end+1:], "=") {
+			return "", "", false, nil
+		}
+		var err error
+		name, err = unescapeWineRegistryString(line[1:end])
+		if err != nil {
+			return "", "", false, err
+		}
+		rest = line[end+2:]
+	default:
+		return "", "", false, nil
+	}
+
+	rest = strings.TrimPrefix(rest, wineRegistryExpandSZPrefix)
+	if !strings.HasPrefix(rest, "\"") {
+		return "", "", false, nil
+	}
+	end := findWineRegistryStringEnd(rest)
+	if end == -1 {
+		return "", "", false, nil
+	}
+	value, err := unescapeWineRegistryString(rest[1:end])
+	if err != nil {
+		return "", "", false, err
+	}
+
+	return name, value, true, nil
+}
+
+// findWineRegistryStringEnd Returns the index of the closing quote of a string starting with a quote (-1 if there is none)
+func findWineRegistryStringEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeWineRegistryString(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '0':
+			b.WriteByte(0)
+		case 'x':
+			// Wine writes any non-ASCII characters as \x followed by up to four hex digits
+			j := i + 1
+			for j < len(s) && j < i+5 && strings.ContainsRune("0123456789abcdefABCDEF", rune(s[j])) {
+				j++
+			}
+			code, err := strconv.ParseUint(s[i+1:j], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid escape sequence in Wine registry string: %s", s)
+			}
+			b.WriteRune(rune(code))
+			i = j - 1
+		default:
+			// Covers \\ and \" plus any unknown escapes
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteRune(r)
+			i += size - 1
+		}
+	}
+	return b.String(), nil
+}
+
+// toWineHostPath Maps a Windows path (e.g. C:\Games\BF2) to the corresponding path in the given Wine prefix
+func toWineHostPath(prefix string, windowsPath string) (string, error) {
+	if len(windowsPath) < 2 || windowsPath[1] != ':' {
+		return "", fmt.Errorf("not an absolute Windows path: %s", windowsPath)
+	}
+
+	drive := strings.ToLower(windowsPath[:1])
+	base := filepath.Join(prefix, wineDosDevicesDirName, drive+":")
+	if drive == "c" {
+		base = filepath.Join(prefix, wineDriveCDirName)
+	}
+
+	elems := []string{base}
+	for _, elem := range strings.Split(windowsPath[2:], "\\") {
+		if elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+
+	return filepath.Join(elems...), nil
+}
@@ -0,0 +1,326 @@
+//go:build unit
+
+package software_finder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testWineSystemRegistry = `WINE REGISTRY Version 2
+;; All keys relative to \\Machine
+
+#arch=win64
+
+[Software\\Wow6432Node\\EA Games\\Battlefield 2] 1700000000
+#time=1da1b2c3d4e5f60
+"InstallDir"="C:\\Program Files (x86)\\EA Games\\Battlefield 2"
+"Language"="English"
+"Version"=dword:00000001
+
+[Software\\Wow6432Node\\Unreal Technology\\Installed Apps\\UT2004] 1700000000
+@="D:\\Games\\UT2004"
+
+[Software\\Wow6432Node\\Some\\Game] 1700000000
+"Path"=str(2):"C:\\Games\\Some \"Quoted\" G\x00e4me"
+`
+	testWineSystemRegistry32 = `WINE REGISTRY Version 2
+;; All keys relative to \\Machine
+
+#arch=win32
+
+[Software\\EA Games\\Battlefield 2] 1700000000
+"InstallDir"="C:\\Program Files\\EA Games\\Battlefield 2"
+`
+	testWineUserRegistry = `WINE REGISTRY Version 2
+;; All keys relative to \\User\\S-1-5-21-0-0-0-1000
+
+[Software\\Some\\Game] 1700000000
+"InstallDir"="C:\\users\\steamuser\\Games\\Game"
+`
+)
+
+func TestSoftwareFinder_GetInstallDir_WinePrefixFinder(t *testing.T) {
+	type test struct {
+		name               string
+		givenConfig        Config
+		expect             func(fr *MockFileRepository)
+		expectedInstallDir string
+		wantErrContains    string
+	}
+
+	tests := []test{
+		{
+			name: "successfully determines install dir via system registry of wine prefix",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "drive_c", "Program Files (x86)", "EA Games", "Battlefield 2")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join("/prefix", "drive_c", "Program Files (x86)", "EA Games", "Battlefield 2"),
+		},
+		{
+			name: "successfully determines install dir via user registry of wine prefix",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyCurrentUser,
+				RegistryPath:      "SOFTWARE\\Some\\Game",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "user.reg")).Return([]byte(testWineUserRegistry), nil)
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "drive_c", "users", "steamuser", "Games", "Game")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join("/prefix", "drive_c", "users", "steamuser", "Games", "Game"),
+		},
+		{
+			name: "successfully determines install dir via proton compatdata dir",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/steam/steamapps/compatdata/1234",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/steam/steamapps/compatdata/1234", "pfx")).Return(true, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/steam/steamapps/compatdata/1234", "pfx", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+				fr.EXPECT().DirExists(filepath.Join("/steam/steamapps/compatdata/1234", "pfx", "drive_c", "Program Files (x86)", "EA Games", "Battlefield 2")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join("/steam/steamapps/compatdata/1234", "pfx", "drive_c", "Program Files (x86)", "EA Games", "Battlefield 2"),
+		},
+		{
+			name: "successfully determines install dir without WOW6432Node in 32-bit wine prefix",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry32), nil)
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "drive_c", "Program Files", "EA Games", "Battlefield 2")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join("/prefix", "drive_c", "Program Files", "EA Games", "Battlefield 2"),
+		},
+		{
+			name: "successfully determines install dir via default value on non-C drive",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\Unreal Technology\\Installed Apps\\UT2004",
+				RegistryValueName: "",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "dosdevices", "d:", "Games", "UT2004")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join("/prefix", "dosdevices", "d:", "Games", "UT2004"),
+		},
+		{
+			name: "successfully determines install dir from escaped expandable string value",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\Some\\Game",
+				RegistryValueName: "Path",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "drive_c", "Games", "Some \"Quoted\" Gäme")).Return(true, nil)
+			},
+			expectedInstallDir: filepath.Join("/prefix", "drive_c", "Games", "Some \"Quoted\" Gäme"),
+		},
+		{
+			name: "errors for value not in wine registry",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "NotAValue",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+			},
+			wantErrContains: "registry value does not exist in Wine prefix",
+		},
+		{
+			name: "errors for missing wine prefix",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "InstallDir",
+			},
+			expect:          func(fr *MockFileRepository) {},
+			wantErrContains: "Wine prefix is missing from config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockRegistryRepository(ctrl)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(mockRepository, mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			installDir, err := finder.GetInstallDir(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInstallDir, installDir)
+			}
+		})
+	}
+}
+
+func TestSoftwareFinder_IsInstalled_WinePrefixFinder(t *testing.T) {
+	type test struct {
+		name            string
+		givenConfig     Config
+		expect          func(fr *MockFileRepository)
+		wantIsInstalled bool
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name: "true for software in wine registry",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+			},
+			wantIsInstalled: true,
+		},
+		{
+			name: "false for software not in wine registry",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\Not\\Installed",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return([]byte(testWineSystemRegistry), nil)
+			},
+			wantIsInstalled: false,
+		},
+		{
+			name: "false for non-existing wine prefix",
+			givenConfig: Config{
+				ForType:           WinePrefixFinder,
+				RegistryKey:       RegistryKeyLocalMachine,
+				RegistryPath:      "SOFTWARE\\WOW6432Node\\EA Games\\Battlefield 2",
+				RegistryValueName: "InstallDir",
+				WinePrefix:        "/prefix",
+			},
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().DirExists(filepath.Join("/prefix", "pfx")).Return(false, nil)
+				fr.EXPECT().ReadFile(filepath.Join("/prefix", "system.reg")).Return(nil, os.ErrNotExist)
+			},
+			wantIsInstalled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockRegistryRepository(ctrl)
+			mockFileRepository := NewMockFileRepository(ctrl)
+			finder := New(mockRepository, mockFileRepository)
+
+			// EXPECT
+			tt.expect(mockFileRepository)
+
+			// WHEN
+			isInstalled, err := finder.IsInstalled(tt.givenConfig)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantIsInstalled, isInstalled)
+			}
+		})
+	}
+}
+
+func TestMakeWinePrefixConfigs(t *testing.T) {
+	// GIVEN
+	registryConfig := Config{
+		ForType:           RegistryFinder,
+		RegistryKey:       RegistryKeyLocalMachine,
+		RegistryPath:      "SOFTWARE\\some\\game",
+		RegistryValueName: "InstallDir",
+	}
+	pathConfig := Config{
+		ForType:     PathFinder,
+		InstallPath: "/some/game",
+		PathType:    PathTypeDir,
+	}
+
+	// WHEN
+	configs := MakeWinePrefixConfigs([]Config{registryConfig, pathConfig}, "/prefix/a", "/prefix/b")
+
+	// THEN
+	assert.Equal(t, []Config{
+		{
+			ForType:           WinePrefixFinder,
+			RegistryKey:       RegistryKeyLocalMachine,
+			RegistryPath:      "SOFTWARE\\some\\game",
+			RegistryValueName: "InstallDir",
+			WinePrefix:        "/prefix/a",
+		},
+		{
+			ForType:           WinePrefixFinder,
+			RegistryKey:       RegistryKeyLocalMachine,
+			RegistryPath:      "SOFTWARE\\some\\game",
+			RegistryValueName: "InstallDir",
+			WinePrefix:        "/prefix/b",
+		},
+	}, configs)
+	// Original config should not have been changed
+	assert.Equal(t, RegistryFinder, registryConfig.ForType)
+}
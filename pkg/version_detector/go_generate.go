@@ -0,0 +1,5 @@
+//go:build ignore
+
+package version_detector
+
+//go:generate mockgen -source=version_detector.go -destination=version_detector_mock_test.go -package=$GOPACKAGE -write_package_comment=false
@@ -0,0 +1,130 @@
+package version_detector
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	peResourceDirectoryIndex = 2
+	peResourceTypeVersion    = 16
+	peResourceSubdirFlag     = 0x80000000
+	peResourceDirHeaderSize  = 16
+	peResourceDirEntrySize   = 8
+	peResourceDataEntrySize  = 16
+	// Signature of the VS_FIXEDFILEINFO structure contained in any version resource
+	peFixedFileInfoSignature = 0xFEEF04BD
+)
+
+// readPEFileVersion Reads the file version from the version resource (VS_FIXEDFILEINFO) of a PE executable
+func readPEFileVersion(content []byte) (string, error) {
+	f, err := pe.NewFile(bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	defer func(f *pe.File) {
+		_ = f.Close()
+	}(f)
+
+	var dir pe.DataDirectory
+	switch header := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if header.NumberOfRvaAndSizes > peResourceDirectoryIndex {
+			dir = header.DataDirectory[peResourceDirectoryIndex]
+		}
+	case *pe.OptionalHeader64:
+		if header.NumberOfRvaAndSizes > peResourceDirectoryIndex {
+			dir = header.DataDirectory[peResourceDirectoryIndex]
+		}
+	}
+	if dir.VirtualAddress == 0 || dir.Size == 0 {
+		return "", fmt.Errorf("executable does not contain any resources")
+	}
+
+	section, data, err := findPESection(f, dir.VirtualAddress)
+	if err != nil {
+		return "", err
+	}
+	resources := data[dir.VirtualAddress-section.VirtualAddress:]
+
+	// Resource tree has three levels: type -> name -> language, we want the first version resource of any name/language
+	offset, err := findPEResourceEntry(resources, 0, peResourceTypeVersion)
+	if err != nil {
+		return "", err
+	}
+	for level := 0; level < 2; level++ {
+		if offset&peResourceSubdirFlag == 0 {
+			break
+		}
+		if offset, err = findPEResourceEntry(resources, offset&^peResourceSubdirFlag, -1); err != nil {
+			return "", err
+		}
+	}
+	if offset&peResourceSubdirFlag != 0 || int(offset)+peResourceDataEntrySize > len(resources) {
+		return "", fmt.Errorf("invalid version resource")
+	}
+
+	dataRVA := binary.LittleEndian.Uint32(resources[offset:])
+	dataSize := binary.LittleEndian.Uint32(resources[offset+4:])
+	start := int64(dataRVA) - int64(section.VirtualAddress)
+	if start < 0 || start+int64(dataSize) > int64(len(data)) {
+		return "", fmt.Errorf("version resource is outside of resource section")
+	}
+
+	return parseFixedFileInfo(data[start : start+int64(dataSize)])
+}
+
+func findPESection(f *pe.File, rva uint32) (*pe.Section, []byte, error) {
+	for _, section := range f.Sections {
+		if rva >= section.VirtualAddress && rva < section.VirtualAddress+section.VirtualSize {
+			data, err := section.Data()
+			if err != nil {
+				return nil, nil, err
+			}
+			if uint32(len(data)) < rva-section.VirtualAddress {
+				return nil, nil, fmt.Errorf("resource directory is outside of section data")
+			}
+			return section, data, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no section contains resource directory")
+}
+
+// findPEResourceEntry Returns the data offset of the resource directory entry with the given id (-1 for the first entry)
+func findPEResourceEntry(resources []byte, dirOffset uint32, id int) (uint32, error) {
+	if int(dirOffset)+peResourceDirHeaderSize > len(resources) {
+		return 0, fmt.Errorf("invalid resource directory")
+	}
+
+	named := int(binary.LittleEndian.Uint16(resources[dirOffset+12:]))
+	ids := int(binary.LittleEndian.Uint16(resources[dirOffset+14:]))
+	for i := 0; i < named+ids; i++ {
+		entryOffset := int(dirOffset) + peResourceDirHeaderSize + i*peResourceDirEntrySize
+		if entryOffset+peResourceDirEntrySize > len(resources) {
+			return 0, fmt.Errorf("invalid resource directory entry")
+		}
+
+		// Named entries come first and never match an id
+		if id == -1 || (i >= named && binary.LittleEndian.Uint32(resources[entryOffset:]) == uint32(id)) {
+			return binary.LittleEndian.Uint32(resources[entryOffset+4:]), nil
+		}
+	}
+
+	return 0, fmt.Errorf("executable does not contain version info")
+}
+
+func parseFixedFileInfo(data []byte) (string, error) {
+	signature := make([]byte, 4)
+	binary.LittleEndian.PutUint32(signature, peFixedFileInfoSignature)
+	i := bytes.Index(data, signature)
+	// Signature is followed by the structure version, then the most/least significant file version parts
+	if i == -1 || i+16 > len(data) {
+		return "", fmt.Errorf("version resource does not contain fixed file info")
+	}
+
+	ms := binary.LittleEndian.Uint32(data[i+8:])
+	ls := binary.LittleEndian.Uint32(data[i+12:])
+	return fmt.Sprintf("%d.%d.%d.%d", ms>>16, ms&0xFFFF, ls>>16, ls&0xFFFF), nil
+}
@@ -0,0 +1,81 @@
+package version_detector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type FileRepository interface {
+	ReadFile(path string) ([]byte, error)
+}
+
+type VersionDetector struct {
+	fileRepository FileRepository
+}
+
+func New(fileRepository FileRepository) *VersionDetector {
+	return &VersionDetector{
+		fileRepository: fileRepository,
+	}
+}
+
+// DetectVersion Determines the version of the given executable based on its PE file version
+func (d *VersionDetector) DetectVersion(path string) (string, error) {
+	content, err := d.fileRepository.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := readPEFileVersion(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to read version info from %s: %w", path, err)
+	}
+
+	return version, nil
+}
+
+// CompareVersions Compares two dot-separated numeric versions, returning -1 if a < b, 0 if a == b and 1 if a > b.
+// Missing components are treated as zero, so 1.5 equals 1.5.0.0.
+func CompareVersions(a string, b string) (int, error) {
+	aParts, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bParts, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		if aPart < bPart {
+			return -1, nil
+		}
+		if aPart > bPart {
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func parseVersion(version string) ([]int, error) {
+	elems := strings.Split(version, ".")
+	parts := make([]int, 0, len(elems))
+	for _, elem := range elems {
+		part, err := strconv.Atoi(elem)
+		if err != nil || part < 0 {
+			return nil, fmt.Errorf("invalid version: %s", version)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
@@ -0,0 +1,226 @@
+//go:build unit
+
+package version_detector
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVersionDetector_DetectVersion(t *testing.T) {
+	type test struct {
+		name            string
+		expect          func(fr *MockFileRepository)
+		expectedVersion string
+		wantErrContains string
+	}
+
+	path := "C:\\Games\\BF2\\BF2.exe"
+	exe := buildPE(t, &[4]uint16{1, 5, 3153, 0})
+
+	tests := []test{
+		{
+			name: "successfully detects version via PE version info",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(path).Return(exe, nil)
+			},
+			expectedVersion: "1.5.3153.0",
+		},
+		{
+			name: "error for executable without version info",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(path).Return(buildPE(t, nil), nil)
+			},
+			wantErrContains: "executable does not contain any resources",
+		},
+		{
+			name: "error for non-PE file",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(path).Return([]byte("#!/bin/sh"), nil)
+			},
+			wantErrContains: "failed to read version info",
+		},
+		{
+			name: "error reading executable",
+			expect: func(fr *MockFileRepository) {
+				fr.EXPECT().ReadFile(path).Return(nil, os.ErrNotExist)
+			},
+			wantErrContains: os.ErrNotExist.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			mockRepository := NewMockFileRepository(ctrl)
+			detector := New(mockRepository)
+
+			// EXPECT
+			tt.expect(mockRepository)
+
+			// WHEN
+			version, err := detector.DetectVersion(path)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	type test struct {
+		name            string
+		givenA          string
+		givenB          string
+		expected        int
+		wantErrContains string
+	}
+
+	tests := []test{
+		{
+			name:     "lower version",
+			givenA:   "1.4.2",
+			givenB:   "1.5",
+			expected: -1,
+		},
+		{
+			name:     "equal version with different number of parts",
+			givenA:   "1.5.0.0",
+			givenB:   "1.5",
+			expected: 0,
+		},
+		{
+			name:     "higher version compared numerically",
+			givenA:   "1.10",
+			givenB:   "1.9",
+			expected: 1,
+		},
+		{
+			name:            "error for non-numeric version",
+			givenA:          "1.5a",
+			givenB:          "1.5",
+			wantErrContains: "invalid version: 1.5a",
+		},
+		{
+			name:            "error for empty version",
+			givenA:          "1.5",
+			givenB:          "",
+			wantErrContains: "invalid version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			comparison, err := CompareVersions(tt.givenA, tt.givenB)
+
+			// THEN
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, comparison)
+			}
+		})
+	}
+}
+
+// buildPE Builds a minimal 32-bit PE file with a single resource section containing a version resource
+// with the given file version (or no resources at all if version is nil)
+func buildPE(t *testing.T, version *[4]uint16) []byte {
+	t.Helper()
+
+	const (
+		sectionRVA        = 0x1000
+		sectionFileOffset = 0x200
+		peHeaderOffset    = 0x40
+	)
+
+	var resources []byte
+	if version != nil {
+		rsrc := new(bytes.Buffer)
+		writeResourceDir := func(id uint32, offset uint32) {
+			// Characteristics, timestamp, major/minor version, 0 named entries, 1 id entry
+			write(t, rsrc, [4]uint32{0, 0, 0, 1 << 16})
+			write(t, rsrc, [2]uint32{id, offset})
+		}
+		writeResourceDir(16, 0x80000000|0x18)
+		writeResourceDir(1, 0x80000000|0x30)
+		writeResourceDir(0x409, 0x48)
+
+		versionInfo := new(bytes.Buffer)
+		// VS_VERSIONINFO header (length, value length, type) followed by its key, then VS_FIXEDFILEINFO
+		write(t, versionInfo, [3]uint16{0, 52, 0})
+		for _, c := range "VS_VERSION_INFO\x00" {
+			write(t, versionInfo, uint16(c))
+		}
+		write(t, versionInfo, uint16(0))
+		write(t, versionInfo, [4]uint32{
+			0xFEEF04BD,
+			0x00010000,
+			uint32(version[0])<<16 | uint32(version[1]),
+			uint32(version[2])<<16 | uint32(version[3]),
+		})
+		write(t, versionInfo, [9]uint32{})
+
+		write(t, rsrc, [4]uint32{sectionRVA + 0x58, uint32(versionInfo.Len()), 0, 0})
+		rsrc.Write(make([]byte, 0x58-rsrc.Len()))
+		rsrc.Write(versionInfo.Bytes())
+		resources = rsrc.Bytes()
+	}
+
+	optionalHeader := pe.OptionalHeader32{
+		Magic:               0x10b,
+		SectionAlignment:    0x1000,
+		FileAlignment:       0x200,
+		SizeOfImage:         0x2000,
+		SizeOfHeaders:       sectionFileOffset,
+		NumberOfRvaAndSizes: 16,
+	}
+	if version != nil {
+		optionalHeader.DataDirectory[2] = pe.DataDirectory{VirtualAddress: sectionRVA, Size: uint32(len(resources))}
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("MZ")
+	buf.Write(make([]byte, 0x3c-buf.Len()))
+	write(t, buf, uint32(peHeaderOffset))
+	buf.Write(make([]byte, peHeaderOffset-buf.Len()))
+	buf.WriteString("PE\x00\x00")
+	write(t, buf, pe.FileHeader{
+		Machine:              pe.IMAGE_FILE_MACHINE_I386,
+		NumberOfSections:     1,
+		SizeOfOptionalHeader: uint16(binary.Size(optionalHeader)),
+	})
+	write(t, buf, optionalHeader)
+	write(t, buf, pe.SectionHeader32{
+		Name:             [8]uint8{'.', 'r', 's', 'r', 'c'},
+		VirtualSize:      uint32(len(resources)),
+		VirtualAddress:   sectionRVA,
+		SizeOfRawData:    uint32(len(resources)),
+		PointerToRawData: sectionFileOffset,
+	})
+	buf.Write(make([]byte, sectionFileOffset-buf.Len()))
+	buf.Write(resources)
+
+	return buf.Bytes()
+}
+
+func write(t *testing.T, buf *bytes.Buffer, data interface{}) {
+	t.Helper()
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, data), fmt.Sprintf("failed to write %T", data))
+}